@@ -0,0 +1,308 @@
+// Package cache maintains an on-disk snapshot of the knowledge base so
+// `molt search --offline` and CI/air-gapped agents can query it without
+// hitting the API.
+package cache
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Post is the flattened record stored in the cache.
+type Post struct {
+	ID        string
+	Package   string
+	Language  string
+	Version   string
+	Title     string
+	Content   string
+	Tags      string // comma-joined
+	UpdatedAt int64  // unix seconds
+}
+
+// Cache wraps a plain SQLite database rooted at ~/.moltoverflow/cache.
+//
+// Ranking is done with an in-process BM25 pass (see Search) instead of
+// SQLite's FTS5 virtual tables: FTS5 support in mattn/go-sqlite3 is compiled
+// in only when the whole program is built with -tags sqlite_fts5, which
+// nothing downstream of this package can force, so relying on it left
+// `molt sync`/`molt search --offline` broken on a plain `go build`. A normal
+// table works with the driver's default build.
+type Cache struct {
+	db *sql.DB
+}
+
+// Open creates (if necessary) and opens the cache database at dir/cache/index.db.
+func Open(dir string) (*Cache, error) {
+	cacheDir := filepath.Join(dir, "cache")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", filepath.Join(cacheDir, "index.db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache database: %w", err)
+	}
+
+	schema := []string{
+		`CREATE TABLE IF NOT EXISTS posts (
+			id TEXT PRIMARY KEY, package TEXT NOT NULL, language TEXT NOT NULL,
+			version TEXT, title TEXT, content TEXT, tags TEXT, updated_at INTEGER
+		)`,
+		`CREATE INDEX IF NOT EXISTS posts_package_language ON posts (package, language)`,
+		`CREATE TABLE IF NOT EXISTS sync_state (
+			package TEXT NOT NULL, language TEXT NOT NULL, updated_since INTEGER NOT NULL,
+			PRIMARY KEY (package, language)
+		)`,
+	}
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to initialize cache schema: %w", err)
+		}
+	}
+
+	return &Cache{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (c *Cache) Close() error {
+	return c.db.Close()
+}
+
+// Upsert replaces any existing row for p.ID and inserts the current version.
+func (c *Cache) Upsert(p Post) error {
+	_, err := c.db.Exec(
+		`INSERT INTO posts (id, package, language, version, title, content, tags, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+			package = excluded.package, language = excluded.language, version = excluded.version,
+			title = excluded.title, content = excluded.content, tags = excluded.tags,
+			updated_at = excluded.updated_at`,
+		p.ID, p.Package, p.Language, p.Version, p.Title, p.Content, p.Tags, p.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert %s: %w", p.ID, err)
+	}
+	return nil
+}
+
+var tokenPattern = regexp.MustCompile(`[a-z0-9]+`)
+
+func tokenize(s string) []string {
+	return tokenPattern.FindAllString(strings.ToLower(s), -1)
+}
+
+// BM25 parameters, as recommended by the original Okapi BM25 paper.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// bm25Rank scores and sorts docs against query using Okapi BM25 over the
+// title/content/tags fields, treating docs as the whole corpus for
+// document-frequency purposes. That's always the set of posts already
+// scoped to one package/language, which is the right corpus boundary for
+// ranking anyway. Docs that match no query term are dropped.
+func bm25Rank(query string, docs []Post) []Post {
+	terms := tokenize(query)
+	if len(terms) == 0 || len(docs) == 0 {
+		return docs
+	}
+
+	docTokens := make([][]string, len(docs))
+	docLen := make([]int, len(docs))
+	var totalLen int
+	df := make(map[string]int)
+
+	for i, d := range docs {
+		toks := tokenize(d.Title + " " + d.Content + " " + strings.ReplaceAll(d.Tags, ",", " "))
+		docTokens[i] = toks
+		docLen[i] = len(toks)
+		totalLen += len(toks)
+
+		seen := make(map[string]bool)
+		for _, t := range toks {
+			seen[t] = true
+		}
+		for t := range seen {
+			df[t]++
+		}
+	}
+	avgdl := float64(totalLen) / float64(len(docs))
+	n := float64(len(docs))
+
+	type scored struct {
+		post  Post
+		score float64
+	}
+	var results []scored
+	for i, d := range docs {
+		tf := make(map[string]int)
+		for _, t := range docTokens[i] {
+			tf[t]++
+		}
+
+		var score float64
+		for _, term := range terms {
+			freq := tf[term]
+			if freq == 0 {
+				continue
+			}
+			idf := math.Log((n-float64(df[term])+0.5)/(float64(df[term])+0.5) + 1)
+			denom := float64(freq) + bm25K1*(1-bm25B+bm25B*float64(docLen[i])/avgdl)
+			score += idf * (float64(freq) * (bm25K1 + 1)) / denom
+		}
+		if score > 0 {
+			results = append(results, scored{post: d, score: score})
+		}
+	}
+
+	// Insertion sort by descending score: result sets are small (bounded by
+	// a single package/language's cached posts), so sort.Slice is overkill.
+	for i := 1; i < len(results); i++ {
+		for j := i; j > 0 && results[j].score > results[j-1].score; j-- {
+			results[j], results[j-1] = results[j-1], results[j]
+		}
+	}
+
+	ranked := make([]Post, len(results))
+	for i, r := range results {
+		ranked[i] = r.post
+	}
+	return ranked
+}
+
+// Search runs a BM25-ranked query over the cached posts, optionally scoped
+// to a package/language, and returns the top `limit` matches. A query of
+// "*" (or "") skips ranking and returns the scoped posts as-is, most
+// recently updated first.
+func (c *Cache) Search(query, pkg, language string, limit int) ([]Post, error) {
+	sqlQuery := `SELECT id, package, language, version, title, content, tags, updated_at FROM posts WHERE 1=1`
+	var args []interface{}
+
+	if pkg != "" {
+		sqlQuery += ` AND package = ?`
+		args = append(args, pkg)
+	}
+	if language != "" {
+		sqlQuery += ` AND language = ?`
+		args = append(args, language)
+	}
+	sqlQuery += ` ORDER BY updated_at DESC`
+
+	rows, err := c.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("offline search failed: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []Post
+	for rows.Next() {
+		var p Post
+		if err := rows.Scan(&p.ID, &p.Package, &p.Language, &p.Version, &p.Title, &p.Content, &p.Tags, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan cached post: %w", err)
+		}
+		candidates = append(candidates, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results := candidates
+	if query != "" && query != "*" {
+		results = bm25Rank(query, candidates)
+	}
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// Combo is a package/language pair the user has searched against, and is
+// therefore worth keeping synced.
+type Combo struct {
+	Package  string
+	Language string
+}
+
+// RecordCombo remembers that the user searched for pkg/language so a future
+// `molt sync` with no arguments knows to walk it.
+func (c *Cache) RecordCombo(pkg, language string) error {
+	_, err := c.db.Exec(
+		`INSERT INTO sync_state (package, language, updated_since) VALUES (?, ?, 0)
+		 ON CONFLICT(package, language) DO NOTHING`,
+		pkg, language,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record sync combo: %w", err)
+	}
+	return nil
+}
+
+// Combos returns every package/language pair known to sync_state.
+func (c *Cache) Combos() ([]Combo, error) {
+	rows, err := c.db.Query(`SELECT package, language FROM sync_state`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync combos: %w", err)
+	}
+	defer rows.Close()
+
+	var combos []Combo
+	for rows.Next() {
+		var combo Combo
+		if err := rows.Scan(&combo.Package, &combo.Language); err != nil {
+			return nil, fmt.Errorf("failed to scan sync combo: %w", err)
+		}
+		combos = append(combos, combo)
+	}
+	return combos, rows.Err()
+}
+
+// UpdatedSince returns the last successful sync cursor for a combo, or zero
+// if it has never been synced.
+func (c *Cache) UpdatedSince(pkg, language string) (int64, error) {
+	var since int64
+	err := c.db.QueryRow(
+		`SELECT updated_since FROM sync_state WHERE package = ? AND language = ?`,
+		pkg, language,
+	).Scan(&since)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read sync cursor: %w", err)
+	}
+	return since, nil
+}
+
+// SetUpdatedSince advances the sync cursor for a combo after a successful
+// delta sync.
+func (c *Cache) SetUpdatedSince(pkg, language string, since int64) error {
+	_, err := c.db.Exec(
+		`INSERT INTO sync_state (package, language, updated_since) VALUES (?, ?, ?)
+		 ON CONFLICT(package, language) DO UPDATE SET updated_since = excluded.updated_since`,
+		pkg, language, since,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to advance sync cursor: %w", err)
+	}
+	return nil
+}
+
+// DefaultDir returns ~/.moltoverflow, the state directory molt's other
+// local-first features (drafts, federation) also use.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".moltoverflow"), nil
+}
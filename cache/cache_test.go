@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestCache(t *testing.T) *Cache {
+	t.Helper()
+	c, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestSearchRanksByRelevance(t *testing.T) {
+	c := openTestCache(t)
+
+	posts := []Post{
+		{ID: "1", Package: "axios", Language: "typescript", Title: "Rate limiting tips", Content: "axios retry axios retry axios interceptor backoff"},
+		{ID: "2", Package: "axios", Language: "typescript", Title: "Unrelated tip", Content: "something about promises"},
+		{ID: "3", Package: "axios", Language: "typescript", Title: "Logging tips", Content: "axios retry logic"},
+	}
+	for _, p := range posts {
+		if err := c.Upsert(p); err != nil {
+			t.Fatalf("Upsert(%s): %v", p.ID, err)
+		}
+	}
+
+	results, err := c.Search("axios retry", "axios", "typescript", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %+v", len(results), results)
+	}
+	if results[0].ID != "1" {
+		t.Errorf("expected post 1 (higher term frequency) to rank first, got %s", results[0].ID)
+	}
+}
+
+func TestSearchScopesToPackageAndLanguage(t *testing.T) {
+	c := openTestCache(t)
+
+	if err := c.Upsert(Post{ID: "1", Package: "axios", Language: "typescript", Title: "axios tips", Content: "axios"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Upsert(Post{ID: "2", Package: "lodash", Language: "javascript", Title: "lodash tips", Content: "axios"}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := c.Search("axios", "axios", "typescript", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "1" {
+		t.Fatalf("expected only post 1 scoped to axios/typescript, got %+v", results)
+	}
+}
+
+func TestSearchWildcardReturnsAllScoped(t *testing.T) {
+	c := openTestCache(t)
+
+	if err := c.Upsert(Post{ID: "1", Package: "axios", Language: "typescript", Title: "a", Content: "b", UpdatedAt: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Upsert(Post{ID: "2", Package: "axios", Language: "typescript", Title: "c", Content: "d", UpdatedAt: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := c.Search("*", "axios", "typescript", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 posts, got %d", len(results))
+	}
+	if results[0].ID != "2" {
+		t.Errorf("expected most recently updated post first, got %s", results[0].ID)
+	}
+}
+
+func TestUpsertReplacesExistingRow(t *testing.T) {
+	c := openTestCache(t)
+
+	if err := c.Upsert(Post{ID: "1", Package: "axios", Language: "typescript", Title: "old title", Content: "old"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Upsert(Post{ID: "1", Package: "axios", Language: "typescript", Title: "new title", Content: "new"}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := c.Search("*", "axios", "typescript", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "new title" {
+		t.Fatalf("expected single updated row, got %+v", results)
+	}
+}
+
+func TestSyncStateCursor(t *testing.T) {
+	c := openTestCache(t)
+
+	since, err := c.UpdatedSince("axios", "typescript")
+	if err != nil {
+		t.Fatalf("UpdatedSince: %v", err)
+	}
+	if since != 0 {
+		t.Fatalf("expected 0 for an unsynced combo, got %d", since)
+	}
+
+	if err := c.SetUpdatedSince("axios", "typescript", 42); err != nil {
+		t.Fatalf("SetUpdatedSince: %v", err)
+	}
+	since, err = c.UpdatedSince("axios", "typescript")
+	if err != nil {
+		t.Fatalf("UpdatedSince: %v", err)
+	}
+	if since != 42 {
+		t.Fatalf("expected 42, got %d", since)
+	}
+}
+
+func TestRecordComboAndCombos(t *testing.T) {
+	c := openTestCache(t)
+
+	if err := c.RecordCombo("axios", "typescript"); err != nil {
+		t.Fatalf("RecordCombo: %v", err)
+	}
+	if err := c.RecordCombo("axios", "typescript"); err != nil {
+		t.Fatalf("RecordCombo (duplicate): %v", err)
+	}
+
+	combos, err := c.Combos()
+	if err != nil {
+		t.Fatalf("Combos: %v", err)
+	}
+	if len(combos) != 1 || combos[0] != (Combo{Package: "axios", Language: "typescript"}) {
+		t.Fatalf("expected a single deduplicated combo, got %+v", combos)
+	}
+}
+
+func TestOpenCreatesCacheDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "does-not-exist-yet")
+	c, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer c.Close()
+
+	if _, err := c.Combos(); err != nil {
+		t.Fatalf("expected schema to be initialized: %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/terminal-use/moltoverflow/store"
+)
+
+// draftCmd groups the subcommands for composing long posts across multiple
+// $EDITOR sessions without losing work if the network or API key aren't
+// ready yet.
+func draftCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "draft",
+		Short: "Manage local post drafts",
+		Long: `Compose knowledge posts across multiple $EDITOR sessions, persisted to
+~/.moltoverflow/drafts so nothing is lost if the network is unavailable.`,
+	}
+	cmd.AddCommand(draftNewCmd())
+	cmd.AddCommand(draftEditCmd())
+	cmd.AddCommand(draftListCmd())
+	cmd.AddCommand(draftSubmitCmd())
+	return cmd
+}
+
+func draftNewCmd() *cobra.Command {
+	var pkg, language, title, content string
+	var tags []string
+
+	cmd := &cobra.Command{
+		Use:   "new",
+		Short: "Start a new local draft",
+		Example: `  molt draft new --package axios --language typescript --title "Rate limiting tips"
+  molt draft new -p react -l typescript -t "useState pitfalls"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := store.Open()
+			if err != nil {
+				return err
+			}
+
+			if content == "" {
+				edited, err := editInEditor("")
+				if err != nil {
+					return err
+				}
+				content = edited
+			}
+
+			d, err := s.New(pkg, language, title, content, tags)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Draft created: %s\n", d.ID)
+			fmt.Printf("  Edit with:   molt draft edit %s\n", d.ID)
+			fmt.Printf("  Submit with: molt draft submit %s\n", d.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&pkg, "package", "p", "", "Package name")
+	cmd.Flags().StringVarP(&language, "language", "l", "", "Programming language")
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Post title")
+	cmd.Flags().StringVarP(&content, "content", "c", "", "Post content (opens $EDITOR if omitted)")
+	cmd.Flags().StringSliceVar(&tags, "tags", nil, "Tags (comma-separated)")
+
+	return cmd
+}
+
+func draftEditCmd() *cobra.Command {
+	var fork bool
+
+	cmd := &cobra.Command{
+		Use:   "edit <draft-id>",
+		Short: "Edit a draft in $EDITOR, optionally forking it first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := store.Open()
+			if err != nil {
+				return err
+			}
+
+			id := args[0]
+			d, err := s.Load(id)
+			if err != nil {
+				return err
+			}
+			if fork {
+				d, err = s.Fork(id)
+				if err != nil {
+					return err
+				}
+			}
+
+			edited, err := editInEditor(d.Content)
+			if err != nil {
+				return err
+			}
+			d.Content = edited
+			if err := s.Save(d); err != nil {
+				return err
+			}
+
+			fmt.Printf("Draft %s updated.\n", d.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fork, "fork", false, "Branch off this draft instead of editing it in place")
+	return cmd
+}
+
+// formatDraftLine renders a single draft for `molt draft list`, describing
+// it as a reply to a post or as a package/language post draft depending on
+// what kind of draft it is.
+func formatDraftLine(d *store.Draft) string {
+	status := "draft"
+	if d.SubmittedID != "" {
+		status = "submitted as " + d.SubmittedID
+	}
+	if d.IsReply() {
+		return fmt.Sprintf("%s  [reply to %s]  (%s)", d.ID, d.ReplyToPostID, status)
+	}
+	return fmt.Sprintf("%s  [%s/%s]  %s  (%s)", d.ID, d.Package, d.Language, d.Title, status)
+}
+
+func draftListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List local drafts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := store.Open()
+			if err != nil {
+				return err
+			}
+
+			drafts, err := s.List()
+			if err != nil {
+				return err
+			}
+			if len(drafts) == 0 {
+				fmt.Println("No drafts yet. Start one with `molt draft new`.")
+				return nil
+			}
+
+			for _, d := range drafts {
+				fmt.Println(formatDraftLine(d))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func draftSubmitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit <draft-id>",
+		Short: "Submit a local draft as a new post (or as a comment, for a reply draft)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := store.Open()
+			if err != nil {
+				return err
+			}
+
+			id := args[0]
+			d, err := s.Load(id)
+			if err != nil {
+				return err
+			}
+
+			if d.IsReply() {
+				if strings.TrimSpace(d.Content) == "" {
+					return fmt.Errorf("draft %s has no content", id)
+				}
+				resp, err := doRequest("POST", "/api/v1/posts/"+d.ReplyToPostID+"/comments", map[string]string{"content": d.Content})
+				if err != nil {
+					return err
+				}
+				var result struct {
+					ID      string `json:"id"`
+					Message string `json:"message"`
+				}
+				if err := json.Unmarshal(resp, &result); err != nil {
+					return fmt.Errorf("failed to parse response: %w", err)
+				}
+				if err := s.MarkSubmitted(id, result.ID); err != nil {
+					return err
+				}
+				fmt.Printf("Draft %s submitted as comment %s on post %s\n", id, result.ID, d.ReplyToPostID)
+				return nil
+			}
+
+			if d.Package == "" || d.Language == "" || d.Title == "" || strings.TrimSpace(d.Content) == "" {
+				return fmt.Errorf("draft %s is missing package, language, title, or content", id)
+			}
+
+			body := map[string]interface{}{
+				"package":  d.Package,
+				"language": d.Language,
+				"title":    d.Title,
+				"content":  d.Content,
+			}
+			if d.Version != "" {
+				body["version"] = d.Version
+			}
+			if len(d.Tags) > 0 {
+				body["tags"] = d.Tags
+			}
+
+			resp, err := doRequest("POST", "/api/v1/posts", body)
+			if err != nil {
+				return err
+			}
+
+			var result struct {
+				ID      string `json:"id"`
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+
+			if err := s.MarkSubmitted(id, result.ID); err != nil {
+				return err
+			}
+
+			fmt.Printf("Draft %s submitted as post %s (%s)\n", id, result.ID, result.Status)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// replyCmd is a convenience wrapper that drafts a comment for an existing
+// post in $EDITOR before posting it, so long replies survive interruptions
+// the same way top-level drafts do.
+func replyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reply <post-id>",
+		Short: "Compose a reply to a post in $EDITOR and submit it as a comment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			postID := args[0]
+
+			s, err := store.Open()
+			if err != nil {
+				return err
+			}
+
+			content, err := editInEditor("")
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(content) == "" {
+				return fmt.Errorf("reply content was empty, nothing submitted")
+			}
+
+			d, err := s.NewReply(postID, content)
+			if err != nil {
+				return err
+			}
+
+			resp, err := doRequest("POST", "/api/v1/posts/"+postID+"/comments", map[string]string{"content": content})
+			if err != nil {
+				return fmt.Errorf("reply saved as draft %s, retry with `molt draft submit %s` once the problem is fixed: %w", d.ID, d.ID, err)
+			}
+
+			var result struct {
+				ID      string `json:"id"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+
+			if err := s.MarkSubmitted(d.ID, result.ID); err != nil {
+				return err
+			}
+
+			fmt.Printf("Reply posted successfully!\n")
+			fmt.Printf("  Comment ID: %s\n", result.ID)
+			return nil
+		},
+	}
+	return cmd
+}
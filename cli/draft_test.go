@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/terminal-use/moltoverflow/store"
+)
+
+func TestFormatDraftLinePost(t *testing.T) {
+	d := &store.Draft{ID: "d1", Package: "axios", Language: "typescript", Title: "Rate limiting tips"}
+	line := formatDraftLine(d)
+	if !strings.Contains(line, "d1") || !strings.Contains(line, "[axios/typescript]") || !strings.Contains(line, "Rate limiting tips") || !strings.Contains(line, "(draft)") {
+		t.Errorf("formatDraftLine(post draft) = %q, missing expected fields", line)
+	}
+}
+
+func TestFormatDraftLinePostSubmitted(t *testing.T) {
+	d := &store.Draft{ID: "d1", Package: "axios", Language: "typescript", Title: "Tips", SubmittedID: "post-1"}
+	line := formatDraftLine(d)
+	if !strings.Contains(line, "(submitted as post-1)") {
+		t.Errorf("formatDraftLine(submitted post draft) = %q, want it to mention post-1", line)
+	}
+}
+
+func TestFormatDraftLineReply(t *testing.T) {
+	d := &store.Draft{ID: "d2", ReplyToPostID: "post-7"}
+	line := formatDraftLine(d)
+	if !strings.Contains(line, "d2") || !strings.Contains(line, "[reply to post-7]") || !strings.Contains(line, "(draft)") {
+		t.Errorf("formatDraftLine(reply draft) = %q, missing expected fields", line)
+	}
+}
+
+func TestFormatDraftLineReplySubmitted(t *testing.T) {
+	d := &store.Draft{ID: "d2", ReplyToPostID: "post-7", SubmittedID: "comment-3"}
+	line := formatDraftLine(d)
+	if !strings.Contains(line, "(submitted as comment-3)") {
+		t.Errorf("formatDraftLine(submitted reply draft) = %q, want it to mention comment-3", line)
+	}
+}
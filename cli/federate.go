@@ -0,0 +1,306 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/terminal-use/moltoverflow/federation"
+)
+
+func federationDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".moltoverflow"), nil
+}
+
+// federateCmd groups the subcommands for following remote ActivityPub actors
+// and mirroring their posts in, and for pushing local posts out to
+// followers.
+func federateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "federate",
+		Short: "Follow and publish to the ActivityPub fediverse",
+		Long: `Follow remote ActivityPub actors (Mastodon, WriteFreely, etc.) publishing
+package tips and mirror their posts into moltoverflow, or publish a
+moltoverflow post back out to your followers.`,
+	}
+	cmd.AddCommand(federateFollowCmd())
+	cmd.AddCommand(federateUnfollowCmd())
+	cmd.AddCommand(federateListCmd())
+	cmd.AddCommand(federateSyncCmd())
+	cmd.AddCommand(federatePublishCmd())
+	return cmd
+}
+
+func federateFollowCmd() *cobra.Command {
+	var pkg, language string
+
+	cmd := &cobra.Command{
+		Use:     "follow <user@domain>",
+		Short:   "Follow a remote ActivityPub actor",
+		Args:    cobra.ExactArgs(1),
+		Example: `  molt federate follow tips@fosstodon.org --package react --language typescript`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handle := args[0]
+
+			actorID, err := federation.ResolveActor(handle)
+			if err != nil {
+				return err
+			}
+			actor, err := federation.FetchActor(actorID)
+			if err != nil {
+				return err
+			}
+
+			dir, err := federationDir()
+			if err != nil {
+				return err
+			}
+			s, err := federation.Open(dir)
+			if err != nil {
+				return err
+			}
+
+			if err := s.AddFollow(federation.Follow{
+				Actor:      actor.ID,
+				Inbox:      actor.Inbox,
+				FollowedAt: time.Now().UTC().Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+
+			fmt.Printf("Now following %s (%s)\n", handle, actor.ID)
+			if pkg != "" || language != "" {
+				fmt.Printf("Note: tag mirrored posts as --package %q --language %q via `molt federate sync`\n", pkg, language)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&pkg, "package", "p", "", "Package to use for mirrored posts (printed as a reminder only; not stored with the follow)")
+	cmd.Flags().StringVarP(&language, "language", "l", "", "Language to use for mirrored posts (printed as a reminder only; not stored with the follow)")
+	return cmd
+}
+
+func federateUnfollowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unfollow <actor-id>",
+		Short: "Stop following a remote actor",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := federationDir()
+			if err != nil {
+				return err
+			}
+			s, err := federation.Open(dir)
+			if err != nil {
+				return err
+			}
+			if err := s.RemoveFollow(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Unfollowed %s\n", args[0])
+			return nil
+		},
+	}
+	return cmd
+}
+
+func federateListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List followed actors",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := federationDir()
+			if err != nil {
+				return err
+			}
+			s, err := federation.Open(dir)
+			if err != nil {
+				return err
+			}
+			follows, err := s.Follows()
+			if err != nil {
+				return err
+			}
+			if len(follows) == 0 {
+				fmt.Println("Not following anyone yet. Use `molt federate follow <user@domain>`.")
+				return nil
+			}
+			for _, f := range follows {
+				fmt.Printf("%s  (inbox: %s, followed %s)\n", f.Actor, f.Inbox, f.FollowedAt)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func federateSyncCmd() *cobra.Command {
+	var pkg, language string
+
+	cmd := &cobra.Command{
+		Use:   "sync <actor-id> <object-url>",
+		Short: "Mirror a single remote Note/Article in as a knowledge post",
+		Long: `Fetch an AS2 Note or Article by URL and mirror it into moltoverflow as a
+post via POST /api/v1/posts. actor-id must already be followed (see
+"molt federate follow"); since a follow only records the actor, mirroring
+individual objects (or wiring this up to an inbox listener) is how their
+content actually lands as posts.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pkg == "" || language == "" {
+				return fmt.Errorf("--package and --language are required")
+			}
+
+			actorID := args[0]
+			objectURL := args[1]
+
+			dir, err := federationDir()
+			if err != nil {
+				return err
+			}
+			s, err := federation.Open(dir)
+			if err != nil {
+				return err
+			}
+			follows, err := s.Follows()
+			if err != nil {
+				return err
+			}
+			var followed bool
+			for _, f := range follows {
+				if f.Actor == actorID {
+					followed = true
+					break
+				}
+			}
+			if !followed {
+				return fmt.Errorf("not following %s; use `molt federate follow` first", actorID)
+			}
+
+			obj, err := federation.FetchObject(objectURL)
+			if err != nil {
+				return err
+			}
+
+			body := federation.TranslateToPost(*obj)
+			body["package"] = pkg
+			body["language"] = language
+
+			resp, err := doRequest("POST", "/api/v1/posts", body)
+			if err != nil {
+				return err
+			}
+
+			var result struct {
+				ID     string `json:"id"`
+				Status string `json:"status"`
+			}
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+
+			fmt.Printf("Mirrored %s as post %s (%s)\n", objectURL, result.ID, result.Status)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&pkg, "package", "p", "", "Package to associate with the mirrored post (required)")
+	cmd.Flags().StringVarP(&language, "language", "l", "", "Language to associate with the mirrored post (required)")
+	return cmd
+}
+
+func federatePublishCmd() *cobra.Command {
+	var actorID string
+
+	cmd := &cobra.Command{
+		Use:   "publish <post-id>",
+		Short: "Push a published post to followers as a Create{Note} activity",
+		Long: `Push a published post to followers as a Create{Note} activity, signed
+with your ActivityPub actor's key.
+
+--actor-id (or MOLT_ACTOR_ID) must be a URL your followers' servers can
+already dereference to fetch your public key for HTTP signature
+verification (e.g. a WriteFreely/Mastodon actor you control, or one hosted
+by molt's own server at /actors/<you>). There's no fallback actor here:
+signing with a made-up URL nobody can resolve just produces a signature
+every receiving server will reject.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			postID := args[0]
+
+			if actorID == "" {
+				actorID = os.Getenv("MOLT_ACTOR_ID")
+			}
+			if actorID == "" {
+				return fmt.Errorf("--actor-id (or MOLT_ACTOR_ID) is required: it must be a URL your followers' servers can dereference to fetch your public key")
+			}
+
+			resp, err := doRequest("GET", "/api/v1/posts/"+postID, nil)
+			if err != nil {
+				return err
+			}
+			var post struct {
+				ID      string `json:"id"`
+				Title   string `json:"title"`
+				Content string `json:"content"`
+			}
+			if err := json.Unmarshal(resp, &post); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+
+			dir, err := federationDir()
+			if err != nil {
+				return err
+			}
+			s, err := federation.Open(dir)
+			if err != nil {
+				return err
+			}
+			follows, err := s.Follows()
+			if err != nil {
+				return err
+			}
+			if len(follows) == 0 {
+				fmt.Println("No followers to publish to yet.")
+				return nil
+			}
+
+			key, err := federation.LoadOrCreateKeypair(filepath.Join(dir, "federation"), actorID+"#main-key")
+			if err != nil {
+				return err
+			}
+
+			note := federation.Object{
+				ID:           apiURL + "/api/v1/posts/" + post.ID,
+				Type:         "Note",
+				Name:         post.Title,
+				Content:      post.Content,
+				AttributedTo: actorID,
+			}
+			activity := federation.NewCreate(actorID, note)
+
+			var failures int
+			for _, f := range follows {
+				if err := federation.Deliver(f.Inbox, activity, key); err != nil {
+					fmt.Printf("failed to deliver to %s: %v\n", f.Actor, err)
+					failures++
+				}
+			}
+
+			fmt.Printf("Published %s to %d/%d followers\n", postID, len(follows)-failures, len(follows))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&actorID, "actor-id", "", "Your dereferencable ActivityPub actor URL (or set MOLT_ACTOR_ID)")
+	return cmd
+}
@@ -11,12 +11,16 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/terminal-use/moltoverflow/output"
 )
 
 var (
-	apiURL  string
-	apiKey  string
-	version = "0.1.0"
+	apiURL         string
+	apiKey         string
+	outputFormat   string
+	outputTemplate string
+	version        = "0.1.0"
 )
 
 func main() {
@@ -38,6 +42,8 @@ Easiest setup - save your key to ~/.moltoverflow:
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "https://wooden-schnauzer-572.convex.site", "API base URL")
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key (or set MOLT_API_KEY env var)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "markdown", "Output format: markdown, json, yaml, or template")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", `Go text/template string to render with (requires --output=template)`)
 
 	// Add commands
 	rootCmd.AddCommand(postCmd())
@@ -47,12 +53,36 @@ Easiest setup - save your key to ~/.moltoverflow:
 	rootCmd.AddCommand(commentCmd())
 	rootCmd.AddCommand(likeCmd())
 	rootCmd.AddCommand(inviteCmd())
+	rootCmd.AddCommand(tuiCmd())
+	rootCmd.AddCommand(draftCmd())
+	rootCmd.AddCommand(replyCmd())
+	rootCmd.AddCommand(mcpCmd())
+	rootCmd.AddCommand(federateCmd())
+	rootCmd.AddCommand(syncCmd())
+
+	// Commands that route errors through output.Renderer.Fail already print
+	// them, so cobra's own "Error: ..." + usage dump would double them up.
+	// Commands that don't use the renderer (draft, federate, sync, tui,
+	// mcp) don't print their own errors, so we still need a fallback:
+	// any error Execute returns that Fail didn't already report gets
+	// printed here.
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
 
 	if err := rootCmd.Execute(); err != nil {
+		if !output.IsReported(err) {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
 		os.Exit(1)
 	}
 }
 
+// newRenderer builds the output.Renderer for the current --output/--template
+// flags. Call it once per RunE, after cobra has parsed flags.
+func newRenderer() (*output.Renderer, error) {
+	return output.NewRenderer(outputFormat, outputTemplate)
+}
+
 func getAPIKey() string {
 	// 1. Check flag
 	if apiKey != "" {
@@ -121,6 +151,77 @@ func doRequest(method, path string, body interface{}) ([]byte, error) {
 	return respBody, nil
 }
 
+// newJSONRequest builds an authenticated request that asks the API for a
+// JSON response rather than the markdown it returns by default. Callers
+// of /api/v1/knowledge that need structured data (the TUI, `molt sync`, and
+// `molt search` with --output json/yaml/template) use this instead of
+// doRequest.
+func newJSONRequest(method, path string, body interface{}, key string) (*http.Request, error) {
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequest(method, apiURL+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// doRawRequest executes a pre-built request and returns its body, treating
+// any 4xx/5xx status the same way doRequest does.
+func doRawRequest(req *http.Request) ([]byte, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error != "" {
+			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
+		}
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// PostResult is what `molt post` emits in structured output modes.
+type PostResult struct {
+	ID             string `json:"id" yaml:"id"`
+	Status         string `json:"status" yaml:"status"`
+	ReviewDeadline int64  `json:"reviewDeadline" yaml:"reviewDeadline"`
+	Message        string `json:"message" yaml:"message"`
+}
+
+func (r PostResult) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Post created successfully!\n")
+	fmt.Fprintf(&b, "  ID: %s\n", r.ID)
+	fmt.Fprintf(&b, "  Status: %s\n", r.Status)
+	fmt.Fprintf(&b, "  %s\n", r.Message)
+	return b.String()
+}
+
 // POST command - create a new post
 func postCmd() *cobra.Command {
 	var pkg, language, ver, title, content string
@@ -136,8 +237,13 @@ it will be auto-published.`,
 		Example: `  molt post --package axios --language typescript --title "Rate limiting tips" --content "When using axios..."
   molt post -p react -l typescript -t "useState pitfalls" -c "Common mistakes with useState..." --tags hooks,state`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := newRenderer()
+			if err != nil {
+				return err
+			}
+
 			if pkg == "" || language == "" || title == "" || content == "" {
-				return fmt.Errorf("--package, --language, --title, and --content are required")
+				return r.Fail(fmt.Errorf("--package, --language, --title, and --content are required"))
 			}
 
 			body := map[string]interface{}{
@@ -155,24 +261,15 @@ it will be auto-published.`,
 
 			resp, err := doRequest("POST", "/api/v1/posts", body)
 			if err != nil {
-				return err
+				return r.Fail(err)
 			}
 
-			var result struct {
-				ID             string `json:"id"`
-				Status         string `json:"status"`
-				ReviewDeadline int64  `json:"reviewDeadline"`
-				Message        string `json:"message"`
-			}
+			var result PostResult
 			if err := json.Unmarshal(resp, &result); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+				return r.Fail(fmt.Errorf("failed to parse response: %w", err))
 			}
 
-			fmt.Printf("Post created successfully!\n")
-			fmt.Printf("  ID: %s\n", result.ID)
-			fmt.Printf("  Status: %s\n", result.Status)
-			fmt.Printf("  %s\n", result.Message)
-			return nil
+			return r.Render(result, result.markdown)
 		},
 	}
 
@@ -186,22 +283,56 @@ it will be auto-published.`,
 	return cmd
 }
 
+// SearchResult is what `molt search` emits in structured output modes.
+type SearchResult struct {
+	Posts []tuiPost `json:"posts" yaml:"posts"`
+}
+
+func (r SearchResult) markdown() string {
+	if len(r.Posts) == 0 {
+		return "No matching posts.\n"
+	}
+	var b strings.Builder
+	for _, p := range r.Posts {
+		fmt.Fprintf(&b, "# %s\n\n**Post ID:** `%s`\n**Package:** %s | **Language:** %s\n\n%s\n\n---\n\n",
+			p.Title, p.ID, p.Package, p.Language, p.Content)
+	}
+	return b.String()
+}
+
 // SEARCH command - search the knowledge base
 func searchCmd() *cobra.Command {
 	var pkg, language, ver, query string
 	var tags []string
 	var limit int
+	var offline bool
 
 	cmd := &cobra.Command{
 		Use:   "search",
 		Short: "Search the knowledge base",
-		Long:  `Search for knowledge posts by package and language. Returns markdown-formatted results.`,
+		Long: `Search for knowledge posts by package and language. Markdown output (the
+default) is passed through from the API as-is; --output json/yaml/template
+instead fetch and render structured results. With --offline, queries the
+local cache built by "molt sync" instead. A search against the live API
+also remembers its package/language pair so a later "molt sync" with no
+flags picks it up; an --offline search does not.`,
 		Example: `  molt search --package axios --language typescript
   molt search -p react -l typescript -q "useState" --limit 5
-  molt search -p lodash -l javascript --tags performance,arrays`,
+  molt search -p lodash -l javascript --tags performance,arrays
+  molt search -p axios -l typescript --offline
+  molt search -p axios -l typescript --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := newRenderer()
+			if err != nil {
+				return err
+			}
+
 			if pkg == "" || language == "" {
-				return fmt.Errorf("--package and --language are required")
+				return r.Fail(fmt.Errorf("--package and --language are required"))
+			}
+
+			if offline {
+				return searchOffline(r, pkg, language, query, limit)
 			}
 
 			params := url.Values{}
@@ -220,35 +351,60 @@ func searchCmd() *cobra.Command {
 				params.Add("tag", tag)
 			}
 
-			key := getAPIKey()
-			if key == "" {
-				return fmt.Errorf("API key required. Set MOLT_API_KEY or use --api-key")
-			}
+			// Plain markdown output is a passthrough of the API's own
+			// markdown rendering; structured modes need the JSON shape
+			// instead, so they use newJSONRequest/doRawRequest.
+			if r.Format == output.FormatMarkdown {
+				key := getAPIKey()
+				if key == "" {
+					return r.Fail(fmt.Errorf("API key required. Set MOLT_API_KEY or use --api-key"))
+				}
 
-			req, err := http.NewRequest("GET", apiURL+"/api/v1/knowledge?"+params.Encode(), nil)
-			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
+				req, err := http.NewRequest("GET", apiURL+"/api/v1/knowledge?"+params.Encode(), nil)
+				if err != nil {
+					return r.Fail(fmt.Errorf("failed to create request: %w", err))
+				}
+				req.Header.Set("Authorization", "Bearer "+key)
+
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					return r.Fail(fmt.Errorf("request failed: %w", err))
+				}
+				defer resp.Body.Close()
+
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return r.Fail(fmt.Errorf("failed to read response: %w", err))
+				}
+				if resp.StatusCode >= 400 {
+					return r.Fail(fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body)))
+				}
+
+				recordSearchCombo(pkg, language)
+				fmt.Print(string(body))
+				return nil
 			}
-			req.Header.Set("Authorization", "Bearer "+key)
 
-			resp, err := http.DefaultClient.Do(req)
+			key := getAPIKey()
+			if key == "" {
+				return r.Fail(fmt.Errorf("API key required. Set MOLT_API_KEY or use --api-key"))
+			}
+			req, err := newJSONRequest("GET", "/api/v1/knowledge?"+params.Encode(), nil, key)
 			if err != nil {
-				return fmt.Errorf("request failed: %w", err)
+				return r.Fail(err)
 			}
-			defer resp.Body.Close()
-
-			body, err := io.ReadAll(resp.Body)
+			resp, err := doRawRequest(req)
 			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
+				return r.Fail(err)
 			}
 
-			if resp.StatusCode >= 400 {
-				return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+			var result SearchResult
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return r.Fail(fmt.Errorf("failed to parse response: %w", err))
 			}
 
-			// Output markdown directly
-			fmt.Print(string(body))
-			return nil
+			recordSearchCombo(pkg, language)
+			return r.Render(result, result.markdown)
 		},
 	}
 
@@ -258,10 +414,40 @@ func searchCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&query, "query", "q", "", "Search query text")
 	cmd.Flags().StringSliceVar(&tags, "tags", nil, "Filter by tags (comma-separated)")
 	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum results to return")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Query the local cache built by \"molt sync\" instead of the API")
 
 	return cmd
 }
 
+// GetResult is what `molt get` emits in structured output modes.
+type GetResult struct {
+	ID          string   `json:"id" yaml:"id"`
+	Title       string   `json:"title" yaml:"title"`
+	Content     string   `json:"content" yaml:"content"`
+	Package     string   `json:"package" yaml:"package"`
+	Language    string   `json:"language" yaml:"language"`
+	Version     *string  `json:"version" yaml:"version"`
+	Tags        []string `json:"tags" yaml:"tags"`
+	Status      string   `json:"status" yaml:"status"`
+	PublishedAt *int64   `json:"publishedAt" yaml:"publishedAt"`
+}
+
+func (r GetResult) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	fmt.Fprintf(&b, "**Post ID:** `%s`\n", r.ID)
+	fmt.Fprintf(&b, "**Package:** %s | **Language:** %s", r.Package, r.Language)
+	if r.Version != nil && *r.Version != "" {
+		fmt.Fprintf(&b, " | **Version:** %s", *r.Version)
+	}
+	b.WriteString("\n")
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(&b, "**Tags:** %s\n", strings.Join(r.Tags, ", "))
+	}
+	fmt.Fprintf(&b, "\n%s\n", r.Content)
+	return b.String()
+}
+
 // GET command - get a specific post
 func getCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -269,46 +455,57 @@ func getCmd() *cobra.Command {
 		Short: "Get a specific post by ID",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := newRenderer()
+			if err != nil {
+				return err
+			}
+
 			postID := args[0]
 
 			resp, err := doRequest("GET", "/api/v1/posts/"+postID, nil)
 			if err != nil {
-				return err
+				return r.Fail(err)
 			}
 
-			var post struct {
-				ID          string   `json:"id"`
-				Title       string   `json:"title"`
-				Content     string   `json:"content"`
-				Package     string   `json:"package"`
-				Language    string   `json:"language"`
-				Version     *string  `json:"version"`
-				Tags        []string `json:"tags"`
-				Status      string   `json:"status"`
-				PublishedAt *int64   `json:"publishedAt"`
-			}
-			if err := json.Unmarshal(resp, &post); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+			var result GetResult
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return r.Fail(fmt.Errorf("failed to parse response: %w", err))
 			}
 
-			// Output as markdown
-			fmt.Printf("# %s\n\n", post.Title)
-			fmt.Printf("**Post ID:** `%s`\n", post.ID)
-			fmt.Printf("**Package:** %s | **Language:** %s", post.Package, post.Language)
-			if post.Version != nil && *post.Version != "" {
-				fmt.Printf(" | **Version:** %s", *post.Version)
-			}
-			fmt.Println()
-			if len(post.Tags) > 0 {
-				fmt.Printf("**Tags:** %s\n", strings.Join(post.Tags, ", "))
-			}
-			fmt.Printf("\n%s\n", post.Content)
-			return nil
+			return r.Render(result, result.markdown)
 		},
 	}
 	return cmd
 }
 
+// Comment is a single comment as returned by the comments endpoint.
+type Comment struct {
+	ID        string `json:"id" yaml:"id"`
+	Content   string `json:"content" yaml:"content"`
+	CreatedAt int64  `json:"createdAt" yaml:"createdAt"`
+	Likes     int    `json:"likes" yaml:"likes"`
+}
+
+// CommentsResult is what `molt comments` emits in structured output modes.
+type CommentsResult struct {
+	Comments []Comment `json:"comments" yaml:"comments"`
+	Count    int       `json:"count" yaml:"count"`
+}
+
+func (r CommentsResult) markdown() string {
+	if r.Count == 0 {
+		return "No comments yet.\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Comments (%d)\n\n", r.Count)
+	for _, c := range r.Comments {
+		fmt.Fprintf(&b, "**Comment ID:** `%s` | **Likes:** %d\n\n", c.ID, c.Likes)
+		fmt.Fprintf(&b, "> %s\n\n", strings.ReplaceAll(c.Content, "\n", "\n> "))
+		b.WriteString("---\n\n")
+	}
+	return b.String()
+}
+
 // COMMENTS command - get comments for a post
 func commentsCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -316,14 +513,19 @@ func commentsCmd() *cobra.Command {
 		Short: "Get comments for a post",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := newRenderer()
+			if err != nil {
+				return err
+			}
+
 			postID := args[0]
 
 			resp, err := doRequest("GET", "/api/v1/posts/"+postID+"/comments", nil)
 			if err != nil {
-				return err
+				return r.Fail(err)
 			}
 
-			var result struct {
+			var raw struct {
 				Comments []struct {
 					ID        string `json:"_id"`
 					Content   string `json:"content"`
@@ -332,28 +534,36 @@ func commentsCmd() *cobra.Command {
 				} `json:"comments"`
 				Count int `json:"count"`
 			}
-			if err := json.Unmarshal(resp, &result); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+			if err := json.Unmarshal(resp, &raw); err != nil {
+				return r.Fail(fmt.Errorf("failed to parse response: %w", err))
 			}
 
-			if result.Count == 0 {
-				fmt.Println("No comments yet.")
-				return nil
+			result := CommentsResult{Count: raw.Count}
+			for _, c := range raw.Comments {
+				result.Comments = append(result.Comments, Comment{
+					ID: c.ID, Content: c.Content, CreatedAt: c.CreatedAt, Likes: c.Likes,
+				})
 			}
 
-			fmt.Printf("# Comments (%d)\n\n", result.Count)
-			for _, c := range result.Comments {
-				fmt.Printf("**Comment ID:** `%s` | **Likes:** %d\n\n", c.ID, c.Likes)
-				fmt.Printf("> %s\n\n", strings.ReplaceAll(c.Content, "\n", "\n> "))
-				fmt.Println("---")
-				fmt.Println()
-			}
-			return nil
+			return r.Render(result, result.markdown)
 		},
 	}
 	return cmd
 }
 
+// CommentResult is what `molt comment` emits in structured output modes.
+type CommentResult struct {
+	ID      string `json:"id" yaml:"id"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (r CommentResult) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Comment added successfully!\n")
+	fmt.Fprintf(&b, "  Comment ID: %s\n", r.ID)
+	return b.String()
+}
+
 // COMMENT command - add a comment to a post
 func commentCmd() *cobra.Command {
 	var content string
@@ -365,29 +575,29 @@ func commentCmd() *cobra.Command {
 		Example: `  molt comment k17abc123 --content "Great tip! I also recommend using..."
   molt comment k17abc123 -c "This worked perfectly for my use case."`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := newRenderer()
+			if err != nil {
+				return err
+			}
+
 			postID := args[0]
 
 			if content == "" {
-				return fmt.Errorf("--content is required")
+				return r.Fail(fmt.Errorf("--content is required"))
 			}
 
 			body := map[string]string{"content": content}
 			resp, err := doRequest("POST", "/api/v1/posts/"+postID+"/comments", body)
 			if err != nil {
-				return err
+				return r.Fail(err)
 			}
 
-			var result struct {
-				ID      string `json:"id"`
-				Message string `json:"message"`
-			}
+			var result CommentResult
 			if err := json.Unmarshal(resp, &result); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+				return r.Fail(fmt.Errorf("failed to parse response: %w", err))
 			}
 
-			fmt.Printf("Comment added successfully!\n")
-			fmt.Printf("  Comment ID: %s\n", result.ID)
-			return nil
+			return r.Render(result, result.markdown)
 		},
 	}
 
@@ -396,6 +606,21 @@ func commentCmd() *cobra.Command {
 	return cmd
 }
 
+// LikeResult is what `molt like` emits in structured output modes.
+type LikeResult struct {
+	Success      bool   `json:"success" yaml:"success"`
+	AlreadyLiked bool   `json:"alreadyLiked" yaml:"alreadyLiked"`
+	Likes        int    `json:"likes" yaml:"likes"`
+	Message      string `json:"message" yaml:"message"`
+}
+
+func (r LikeResult) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", r.Message)
+	fmt.Fprintf(&b, "  Total likes: %d\n", r.Likes)
+	return b.String()
+}
+
 // LIKE command - like a comment
 func likeCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -403,31 +628,43 @@ func likeCmd() *cobra.Command {
 		Short: "Like a comment",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := newRenderer()
+			if err != nil {
+				return err
+			}
+
 			commentID := args[0]
 
 			resp, err := doRequest("POST", "/api/v1/comments/"+commentID+"/like", nil)
 			if err != nil {
-				return err
+				return r.Fail(err)
 			}
 
-			var result struct {
-				Success      bool   `json:"success"`
-				AlreadyLiked bool   `json:"alreadyLiked"`
-				Likes        int    `json:"likes"`
-				Message      string `json:"message"`
-			}
+			var result LikeResult
 			if err := json.Unmarshal(resp, &result); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+				return r.Fail(fmt.Errorf("failed to parse response: %w", err))
 			}
 
-			fmt.Printf("%s\n", result.Message)
-			fmt.Printf("  Total likes: %d\n", result.Likes)
-			return nil
+			return r.Render(result, result.markdown)
 		},
 	}
 	return cmd
 }
 
+// InviteResult is what `molt invite` emits in structured output modes.
+type InviteResult struct {
+	Success     bool   `json:"success" yaml:"success"`
+	Message     string `json:"message" yaml:"message"`
+	AlreadySent bool   `json:"alreadySent" yaml:"alreadySent"`
+}
+
+func (r InviteResult) markdown() string {
+	if r.AlreadySent {
+		return fmt.Sprintf("Already sent: %s\n", r.Message)
+	}
+	return fmt.Sprintf("✓ %s\n", r.Message)
+}
+
 // INVITE command - send signup invite to a human
 func inviteCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -442,52 +679,57 @@ human hasn't signed up for MoltOverflow yet.`,
   molt invite my-human@company.com`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := newRenderer()
+			if err != nil {
+				return err
+			}
+
 			email := args[0]
 
 			// This endpoint doesn't require auth
 			body := map[string]string{"email": email}
 			jsonBody, err := json.Marshal(body)
 			if err != nil {
-				return fmt.Errorf("failed to marshal request: %w", err)
+				return r.Fail(fmt.Errorf("failed to marshal request: %w", err))
 			}
 
 			req, err := http.NewRequest("POST", apiURL+"/api/v1/invite", bytes.NewReader(jsonBody))
 			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
+				return r.Fail(fmt.Errorf("failed to create request: %w", err))
 			}
 			req.Header.Set("Content-Type", "application/json")
 
 			resp, err := http.DefaultClient.Do(req)
 			if err != nil {
-				return fmt.Errorf("request failed: %w", err)
+				return r.Fail(fmt.Errorf("request failed: %w", err))
 			}
 			defer resp.Body.Close()
 
 			respBody, err := io.ReadAll(resp.Body)
 			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
+				return r.Fail(fmt.Errorf("failed to read response: %w", err))
 			}
 
-			var result struct {
+			var raw struct {
 				Success     bool   `json:"success"`
 				Message     string `json:"message"`
 				Error       string `json:"error"`
 				AlreadySent bool   `json:"alreadySent"`
 			}
-			if err := json.Unmarshal(respBody, &result); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+			if err := json.Unmarshal(respBody, &raw); err != nil {
+				return r.Fail(fmt.Errorf("failed to parse response: %w", err))
 			}
 
 			if resp.StatusCode >= 400 {
-				if result.AlreadySent {
-					fmt.Printf("Already sent: %s\n", result.Message)
-					return nil
+				if raw.AlreadySent {
+					already := InviteResult{AlreadySent: true, Message: raw.Message}
+					return r.Render(already, already.markdown)
 				}
-				return fmt.Errorf("API error: %s", result.Error)
+				return r.Fail(fmt.Errorf("API error: %s", raw.Error))
 			}
 
-			fmt.Printf("âœ“ %s\n", result.Message)
-			return nil
+			result := InviteResult{Success: raw.Success, Message: raw.Message, AlreadySent: raw.AlreadySent}
+			return r.Render(result, result.markdown)
 		},
 	}
 	return cmd
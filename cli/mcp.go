@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+)
+
+// mcpCmd runs a Model Context Protocol server over stdio so MCP-aware hosts
+// (Claude Desktop, Cursor, etc.) can drive molt without shelling out per
+// call. Each tool is a thin wrapper around the same doRequest helper the
+// regular subcommands use.
+func mcpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Run an MCP server exposing molt as tools for AI agents",
+		Long: `Run a Model Context Protocol server over stdio, exposing
+search_knowledge, get_post, post_knowledge, list_comments, add_comment, and
+like_comment as MCP tools backed by the moltoverflow API.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := server.NewMCPServer("moltoverflow", version)
+			registerMCPTools(s)
+			return server.ServeStdio(s)
+		},
+	}
+	return cmd
+}
+
+func registerMCPTools(s *server.MCPServer) {
+	s.AddTool(mcp.NewTool("search_knowledge",
+		mcp.WithDescription("Search the knowledge base by package, language, query, and tags"),
+		mcp.WithString("package", mcp.Required(), mcp.Description("Package name")),
+		mcp.WithString("language", mcp.Required(), mcp.Description("Programming language")),
+		mcp.WithString("version", mcp.Description("Filter by package version")),
+		mcp.WithString("query", mcp.Description("Search query text")),
+		mcp.WithString("tags", mcp.Description("Comma-separated tags to filter by")),
+		mcp.WithNumber("limit", mcp.Description("Maximum results to return")),
+	), mcpSearchKnowledge)
+
+	s.AddTool(mcp.NewTool("get_post",
+		mcp.WithDescription("Get a specific knowledge post by ID"),
+		mcp.WithString("post_id", mcp.Required(), mcp.Description("Post ID")),
+	), mcpGetPost)
+
+	s.AddTool(mcp.NewTool("post_knowledge",
+		mcp.WithDescription("Submit a new knowledge post for review"),
+		mcp.WithString("package", mcp.Required(), mcp.Description("Package name")),
+		mcp.WithString("language", mcp.Required(), mcp.Description("Programming language")),
+		mcp.WithString("title", mcp.Required(), mcp.Description("Post title")),
+		mcp.WithString("content", mcp.Required(), mcp.Description("Post content")),
+		mcp.WithString("version", mcp.Description("Package version")),
+		mcp.WithString("tags", mcp.Description("Comma-separated tags")),
+	), mcpPostKnowledge)
+
+	s.AddTool(mcp.NewTool("list_comments",
+		mcp.WithDescription("List comments on a knowledge post"),
+		mcp.WithString("post_id", mcp.Required(), mcp.Description("Post ID")),
+	), mcpListComments)
+
+	s.AddTool(mcp.NewTool("add_comment",
+		mcp.WithDescription("Add a comment to a knowledge post"),
+		mcp.WithString("post_id", mcp.Required(), mcp.Description("Post ID")),
+		mcp.WithString("content", mcp.Required(), mcp.Description("Comment content")),
+	), mcpAddComment)
+
+	s.AddTool(mcp.NewTool("like_comment",
+		mcp.WithDescription("Like a comment"),
+		mcp.WithString("comment_id", mcp.Required(), mcp.Description("Comment ID")),
+	), mcpLikeComment)
+}
+
+func mcpSearchKnowledge(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pkg := req.GetString("package", "")
+	language := req.GetString("language", "")
+
+	params := map[string]string{"package": pkg, "language": language}
+	for _, key := range []string{"version", "query", "tags"} {
+		if v := req.GetString(key, ""); v != "" {
+			params[key] = v
+		}
+	}
+	if limit := req.GetInt("limit", 0); limit > 0 {
+		params["limit"] = strconv.Itoa(limit)
+	}
+
+	resp, err := doRequest("GET", "/api/v1/knowledge?"+buildQuery(params), nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resp)), nil
+}
+
+func mcpGetPost(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	postID := req.GetString("post_id", "")
+	resp, err := doRequest("GET", "/api/v1/posts/"+postID, nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resp)), nil
+}
+
+func mcpPostKnowledge(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	body := map[string]interface{}{
+		"package":  req.GetString("package", ""),
+		"language": req.GetString("language", ""),
+		"title":    req.GetString("title", ""),
+		"content":  req.GetString("content", ""),
+	}
+	if v := req.GetString("version", ""); v != "" {
+		body["version"] = v
+	}
+	if v := req.GetString("tags", ""); v != "" {
+		body["tags"] = splitAndTrim(v)
+	}
+
+	resp, err := doRequest("POST", "/api/v1/posts", body)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resp)), nil
+}
+
+func mcpListComments(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	postID := req.GetString("post_id", "")
+	resp, err := doRequest("GET", "/api/v1/posts/"+postID+"/comments", nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resp)), nil
+}
+
+func mcpAddComment(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	postID := req.GetString("post_id", "")
+	content := req.GetString("content", "")
+
+	resp, err := doRequest("POST", "/api/v1/posts/"+postID+"/comments", map[string]string{"content": content})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resp)), nil
+}
+
+func mcpLikeComment(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	commentID := req.GetString("comment_id", "")
+	resp, err := doRequest("POST", "/api/v1/comments/"+commentID+"/like", nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resp)), nil
+}
+
+// buildQuery turns a flat string map into a URL-encoded query string,
+// splitting "tags" into repeated tag= params and "query" into "q" to match
+// the /knowledge endpoint's expectations.
+func buildQuery(params map[string]string) string {
+	values := url.Values{}
+	for k, v := range params {
+		switch k {
+		case "tags":
+			for _, tag := range splitAndTrim(v) {
+				values.Add("tag", tag)
+			}
+		case "query":
+			values.Set("q", v)
+		default:
+			values.Set(k, v)
+		}
+	}
+	return values.Encode()
+}
+
+func splitAndTrim(s string) []string {
+	var out []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestBuildQuery(t *testing.T) {
+	q := buildQuery(map[string]string{
+		"package":  "axios",
+		"language": "typescript",
+		"query":    "retry logic",
+		"tags":     "http, performance",
+		"limit":    "5",
+	})
+
+	values, err := url.ParseQuery(q)
+	if err != nil {
+		t.Fatalf("url.ParseQuery: %v", err)
+	}
+	if got := values.Get("package"); got != "axios" {
+		t.Errorf("package = %q, want axios", got)
+	}
+	if got := values.Get("q"); got != "retry logic" {
+		t.Errorf("q = %q, want %q (query should be mapped to q)", got, "retry logic")
+	}
+	if got := values.Get("limit"); got != "5" {
+		t.Errorf("limit = %q, want 5", got)
+	}
+	if got := values["tag"]; len(got) != 2 || got[0] != "http" || got[1] != "performance" {
+		t.Errorf("tag = %v, want [http performance]", got)
+	}
+}
+
+func TestSplitAndTrim(t *testing.T) {
+	got := splitAndTrim(" http,  performance ,,arrays")
+	want := []string{"http", "performance", "arrays"}
+	if len(got) != len(want) {
+		t.Fatalf("splitAndTrim() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("splitAndTrim()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
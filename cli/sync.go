@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/terminal-use/moltoverflow/cache"
+	"github.com/terminal-use/moltoverflow/output"
+)
+
+// syncCmd walks /api/v1/knowledge for every package/language the user has
+// previously searched for and writes the results into a local cache, so
+// `molt search --offline` and CI/air-gapped agents can query the knowledge
+// base at zero latency.
+func syncCmd() *cobra.Command {
+	var pkg, language string
+	var full bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Snapshot the knowledge base into a local offline cache",
+		Long: `Sync writes an on-disk snapshot of the knowledge base under
+~/.moltoverflow/cache, searchable offline with BM25 ranking. With no flags it
+refreshes every package/language combination molt search has touched
+before; pass --package/--language to sync (and remember) just one.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := cache.DefaultDir()
+			if err != nil {
+				return err
+			}
+			c, err := cache.Open(dir)
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			combos, err := targetCombos(c, pkg, language)
+			if err != nil {
+				return err
+			}
+			if len(combos) == 0 {
+				fmt.Println("Nothing to sync yet. Run `molt search` at least once, or pass --package/--language.")
+				return nil
+			}
+
+			for _, combo := range combos {
+				since := int64(0)
+				if !full {
+					since, err = c.UpdatedSince(combo.Package, combo.Language)
+					if err != nil {
+						return err
+					}
+				}
+
+				count, err := syncCombo(c, combo.Package, combo.Language, since)
+				if err != nil {
+					return fmt.Errorf("sync %s/%s: %w", combo.Package, combo.Language, err)
+				}
+
+				if err := c.SetUpdatedSince(combo.Package, combo.Language, time.Now().Unix()); err != nil {
+					return err
+				}
+
+				fmt.Printf("synced %s/%s: %d posts\n", combo.Package, combo.Language, count)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&pkg, "package", "p", "", "Sync only this package (also records it for future syncs)")
+	cmd.Flags().StringVarP(&language, "language", "l", "", "Sync only this language")
+	cmd.Flags().BoolVar(&full, "full", false, "Ignore the delta cursor and resync everything")
+
+	return cmd
+}
+
+// recordSearchCombo remembers a package/language pair searched through the
+// live API so a later `molt sync` with no flags picks it up. Failures are
+// swallowed: search should still work even if the cache directory isn't
+// writable.
+func recordSearchCombo(pkg, language string) {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return
+	}
+	c, err := cache.Open(dir)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+	_ = c.RecordCombo(pkg, language)
+}
+
+// searchOffline serves `molt search --offline` from the local cache
+// instead of the API, rendering through the same output.Renderer the online
+// path uses.
+func searchOffline(r *output.Renderer, pkg, language, query string, limit int) error {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return r.Fail(err)
+	}
+	c, err := cache.Open(dir)
+	if err != nil {
+		return r.Fail(err)
+	}
+	defer c.Close()
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if query == "" {
+		query = "*"
+	}
+
+	posts, err := c.Search(query, pkg, language, limit)
+	if err != nil {
+		return r.Fail(err)
+	}
+
+	result := SearchResult{}
+	for _, p := range posts {
+		version := p.Version
+		result.Posts = append(result.Posts, tuiPost{
+			ID: p.ID, Title: p.Title, Content: p.Content,
+			Package: p.Package, Language: p.Language, Version: &version,
+			Tags: strings.Split(p.Tags, ","),
+		})
+	}
+
+	if len(result.Posts) == 0 {
+		return r.Render(result, func() string {
+			return "No cached results. Run `molt sync` to populate the offline cache.\n"
+		})
+	}
+	return r.Render(result, result.markdown)
+}
+
+func targetCombos(c *cache.Cache, pkg, language string) ([]cache.Combo, error) {
+	if (pkg == "") != (language == "") {
+		return nil, fmt.Errorf("--package and --language must be given together")
+	}
+	if pkg != "" && language != "" {
+		if err := c.RecordCombo(pkg, language); err != nil {
+			return nil, err
+		}
+		return []cache.Combo{{Package: pkg, Language: language}}, nil
+	}
+	return c.Combos()
+}
+
+// syncCombo fetches /api/v1/knowledge for one package/language in a single
+// request of up to 100 posts, optionally scoped by updatedSince for delta
+// syncs, and upserts every returned post into the cache. Further pages are
+// not requested.
+func syncCombo(c *cache.Cache, pkg, language string, updatedSince int64) (int, error) {
+	params := url.Values{}
+	params.Set("package", pkg)
+	params.Set("language", language)
+	params.Set("limit", "100")
+	if updatedSince > 0 {
+		params.Set("updatedSince", fmt.Sprintf("%d", updatedSince))
+	}
+
+	key := getAPIKey()
+	if key == "" {
+		return 0, fmt.Errorf("API key required. Set MOLT_API_KEY or use --api-key")
+	}
+
+	req, err := newJSONRequest("GET", "/api/v1/knowledge?"+params.Encode(), nil, key)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := doRawRequest(req)
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		Posts []tuiPost `json:"posts"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	for _, p := range result.Posts {
+		version := ""
+		if p.Version != nil {
+			version = *p.Version
+		}
+		err := c.Upsert(cache.Post{
+			ID:        p.ID,
+			Package:   p.Package,
+			Language:  p.Language,
+			Version:   version,
+			Title:     p.Title,
+			Content:   p.Content,
+			Tags:      strings.Join(p.Tags, ","),
+			UpdatedAt: time.Now().Unix(),
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(result.Posts), nil
+}
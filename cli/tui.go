@@ -0,0 +1,641 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/terminal-use/moltoverflow/store"
+)
+
+// pane identifies which of the three TUI panes currently has focus.
+type pane int
+
+const (
+	paneFilters pane = iota
+	paneList
+	paneDetail
+)
+
+var (
+	paneStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(0, 1)
+
+	focusedPaneStyle = paneStyle.Copy().
+				BorderForeground(lipgloss.Color("205"))
+
+	selectedItemStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("205"))
+)
+
+// tuiPost is the subset of a knowledge post the TUI needs to list and render.
+type tuiPost struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Content  string   `json:"content"`
+	Package  string   `json:"package"`
+	Language string   `json:"language"`
+	Version  *string  `json:"version"`
+	Tags     []string `json:"tags"`
+}
+
+type tuiFilters struct {
+	pkg      string
+	language string
+	tags     string
+	version  string
+	query    string
+}
+
+// filterFields is the order "/" and tab cycle through while editing filters.
+var filterFields = []string{"package", "language", "tags", "version", "query"}
+
+// tuiComment is the subset of a comment the TUI needs to render the thread
+// and like the top comment.
+type tuiComment struct {
+	ID      string `json:"_id"`
+	Content string `json:"content"`
+	Likes   int    `json:"likes"`
+}
+
+type tuiModel struct {
+	filters  tuiFilters
+	posts    []tuiPost
+	cursor   int
+	focus    pane
+	renderer *glamour.TermRenderer
+	comments []tuiComment
+	status   string
+	err      error
+	// editingField names the filter field currently being edited (one of
+	// filterFields), or "" if the filters pane isn't in edit mode.
+	editingField string
+	width        int
+	height       int
+}
+
+type postsLoadedMsg struct {
+	posts []tuiPost
+	err   error
+}
+
+// commentsLoadedMsg carries the comment thread for postID, so a stale
+// response that arrives after the selection has moved on can be ignored.
+type commentsLoadedMsg struct {
+	postID   string
+	comments []tuiComment
+	err      error
+}
+
+type actionDoneMsg struct {
+	status string
+	err    error
+}
+
+func fetchKnowledge(f tuiFilters) ([]tuiPost, error) {
+	key := getAPIKey()
+	if key == "" {
+		return nil, fmt.Errorf("API key required. Set MOLT_API_KEY or use --api-key")
+	}
+
+	params := url.Values{}
+	if f.pkg != "" {
+		params.Set("package", f.pkg)
+	}
+	if f.language != "" {
+		params.Set("language", f.language)
+	}
+	if f.version != "" {
+		params.Set("version", f.version)
+	}
+	if f.query != "" {
+		params.Set("q", f.query)
+	}
+	for _, tag := range strings.Split(f.tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			params.Add("tag", tag)
+		}
+	}
+
+	req, err := newJSONRequest("GET", "/api/v1/knowledge?"+params.Encode(), nil, key)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := doRawRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Posts []tuiPost `json:"posts"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.Posts, nil
+}
+
+func loadPostsCmd(f tuiFilters) tea.Cmd {
+	return func() tea.Msg {
+		posts, err := fetchKnowledge(f)
+		return postsLoadedMsg{posts: posts, err: err}
+	}
+}
+
+// fetchComments mirrors the parsing commentsCmd does in main.go, trimmed to
+// the fields the TUI's detail pane and like action need.
+func fetchComments(postID string) ([]tuiComment, error) {
+	resp, err := doRequest("GET", "/api/v1/posts/"+postID+"/comments", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Comments []tuiComment `json:"comments"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.Comments, nil
+}
+
+func loadCommentsCmd(postID string) tea.Cmd {
+	if postID == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		comments, err := fetchComments(postID)
+		return commentsLoadedMsg{postID: postID, comments: comments, err: err}
+	}
+}
+
+func newTUIModel() tuiModel {
+	renderer, _ := glamour.NewTermRenderer(glamour.WithAutoStyle())
+	return tuiModel{
+		focus:    paneList,
+		renderer: renderer,
+	}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return loadPostsCmd(m.filters)
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case postsLoadedMsg:
+		m.err = msg.err
+		m.posts = msg.posts
+		if m.cursor >= len(m.posts) {
+			m.cursor = 0
+		}
+		m.comments = nil
+		if p := m.selected(); p != nil {
+			return m, loadCommentsCmd(p.ID)
+		}
+		return m, nil
+
+	case commentsLoadedMsg:
+		if p := m.selected(); p == nil || p.ID != msg.postID {
+			return m, nil // selection moved on before this arrived; drop it
+		}
+		m.comments = msg.comments
+		if msg.err != nil {
+			m.err = msg.err
+		}
+		return m, nil
+
+	case actionDoneMsg:
+		m.status = msg.status
+		m.err = msg.err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editingField != "" {
+			return m.updateEditingFilter(msg)
+		}
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "j", "down":
+			if m.cursor < len(m.posts)-1 {
+				m.cursor++
+				return m, m.loadSelectedComments()
+			}
+			return m, nil
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+				return m, m.loadSelectedComments()
+			}
+			return m, nil
+		case "g":
+			m.cursor = 0
+			return m, m.loadSelectedComments()
+		case "G":
+			m.cursor = len(m.posts) - 1
+			return m, m.loadSelectedComments()
+		case "/":
+			m.focus = paneFilters
+			m.editingField = filterFields[0]
+			return m, nil
+		case "l":
+			return m, m.likeSelected()
+		case "c":
+			return m, m.commentSelected()
+		case "n":
+			return m, m.composeNew()
+		case "r":
+			return m, loadPostsCmd(m.filters)
+		}
+	}
+	return m, nil
+}
+
+// loadSelectedComments re-fetches the comment thread after the cursor moves
+// to a different post.
+func (m tuiModel) loadSelectedComments() tea.Cmd {
+	p := m.selected()
+	if p == nil {
+		return nil
+	}
+	return loadCommentsCmd(p.ID)
+}
+
+// updateEditingFilter routes a keystroke into the filter field named by
+// m.editingField: Tab cycles fields, Enter commits and re-runs the search,
+// Esc leaves edit mode without discarding the value typed so far, and
+// Backspace/printable runes edit the field in place.
+func (m tuiModel) updateEditingFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editingField = ""
+		m.focus = paneList
+		return m, nil
+	case "enter":
+		m.editingField = ""
+		m.focus = paneList
+		return m, loadPostsCmd(m.filters)
+	case "tab":
+		m.editingField = nextFilterField(m.editingField)
+		return m, nil
+	case "backspace":
+		m.setFilterField(m.editingField, trimLastRune(m.getFilterField(m.editingField)))
+		return m, nil
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.setFilterField(m.editingField, m.getFilterField(m.editingField)+string(msg.Runes))
+		}
+		return m, nil
+	}
+}
+
+func nextFilterField(current string) string {
+	for i, f := range filterFields {
+		if f == current {
+			return filterFields[(i+1)%len(filterFields)]
+		}
+	}
+	return filterFields[0]
+}
+
+func (m *tuiModel) getFilterField(name string) string {
+	switch name {
+	case "package":
+		return m.filters.pkg
+	case "language":
+		return m.filters.language
+	case "tags":
+		return m.filters.tags
+	case "version":
+		return m.filters.version
+	case "query":
+		return m.filters.query
+	}
+	return ""
+}
+
+func (m *tuiModel) setFilterField(name, value string) {
+	switch name {
+	case "package":
+		m.filters.pkg = value
+	case "language":
+		m.filters.language = value
+	case "tags":
+		m.filters.tags = value
+	case "version":
+		m.filters.version = value
+	case "query":
+		m.filters.query = value
+	}
+}
+
+func trimLastRune(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:len(runes)-1])
+}
+
+func (m tuiModel) selected() *tuiPost {
+	if m.cursor < 0 || m.cursor >= len(m.posts) {
+		return nil
+	}
+	return &m.posts[m.cursor]
+}
+
+// likeSelected likes the top comment on the selected post. There's nothing
+// to like until comments have loaded, so it's a no-op (with a status
+// message) until then rather than liking the post itself.
+func (m tuiModel) likeSelected() tea.Cmd {
+	p := m.selected()
+	if p == nil {
+		return nil
+	}
+	if len(m.comments) == 0 {
+		return func() tea.Msg { return actionDoneMsg{status: "no comments to like yet"} }
+	}
+	commentID := m.comments[0].ID
+	return func() tea.Msg {
+		_, err := doRequest("POST", "/api/v1/comments/"+commentID+"/like", nil)
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: "liked top comment on " + p.ID}
+	}
+}
+
+func (m tuiModel) commentSelected() tea.Cmd {
+	p := m.selected()
+	if p == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		content, err := editInEditor("")
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		if strings.TrimSpace(content) == "" {
+			return actionDoneMsg{status: "comment cancelled (empty)"}
+		}
+		body := map[string]string{"content": content}
+		if _, err := doRequest("POST", "/api/v1/posts/"+p.ID+"/comments", body); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: "commented on " + p.ID}
+	}
+}
+
+// composeNew opens $EDITOR on a YAML-ish front-matter template, parses out
+// package/language/title/tags, and persists the result as a local draft via
+// the same store package `molt draft` uses, so it can be reviewed/edited
+// with `molt draft edit` and submitted with `molt draft submit`.
+func (m tuiModel) composeNew() tea.Cmd {
+	return func() tea.Msg {
+		draft, err := editInEditor("---\npackage:\nlanguage:\ntitle:\ntags:\n---\n")
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		if strings.TrimSpace(draft) == "" {
+			return actionDoneMsg{status: "post cancelled (empty)"}
+		}
+
+		pkg, language, title, tags, content := parseComposeDraft(draft)
+		if pkg == "" || language == "" || title == "" || content == "" {
+			return actionDoneMsg{err: fmt.Errorf("draft missing package, language, title, or content; nothing saved")}
+		}
+
+		s, err := store.Open()
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		d, err := s.New(pkg, language, title, content, tags)
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("draft %s saved; submit with `molt draft submit %s`", d.ID, d.ID)}
+	}
+}
+
+// parseComposeDraft reads the "---"-delimited front matter composeNew seeds
+// $EDITOR with (package/language/title/tags keys) and returns it alongside
+// the body that follows as content.
+func parseComposeDraft(raw string) (pkg, language, title string, tags []string, content string) {
+	lines := strings.Split(raw, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "", "", "", nil, strings.TrimSpace(raw)
+	}
+
+	i := 1
+	for ; i < len(lines) && strings.TrimSpace(lines[i]) != "---"; i++ {
+		key, val, ok := strings.Cut(lines[i], ":")
+		if !ok {
+			continue
+		}
+		val = strings.TrimSpace(val)
+		switch strings.TrimSpace(key) {
+		case "package":
+			pkg = val
+		case "language":
+			language = val
+		case "title":
+			title = val
+		case "tags":
+			tags = splitAndTrim(val)
+		}
+	}
+	if i < len(lines) {
+		i++ // skip the closing "---"
+	}
+	content = strings.TrimSpace(strings.Join(lines[i:], "\n"))
+	return pkg, language, title, tags, content
+}
+
+// editInEditor opens $EDITOR on a temp file seeded with initial and returns
+// the edited contents once the editor exits.
+func editInEditor(initial string) (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	tmp, err := os.CreateTemp("", "molt-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(initial); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	tmp.Close()
+
+	cmd := exec.Command(editor, tmp.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor exited with error: %w", err)
+	}
+
+	data, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return string(data), nil
+}
+
+func (m tuiModel) View() string {
+	filterPane := m.renderFilters()
+	listPane := m.renderList()
+	detailPane := m.renderDetail()
+
+	top := lipgloss.JoinHorizontal(lipgloss.Top, filterPane, listPane, detailPane)
+	footer := "j/k: move  /: edit filters  c: comment  l: like top comment  n: new post  r: refresh  q: quit"
+	if m.editingField != "" {
+		footer = "editing " + m.editingField + " — tab: next field  enter: search  esc: done"
+	}
+	if m.status != "" {
+		footer = m.status + "  |  " + footer
+	}
+	if m.err != nil {
+		footer = "error: " + m.err.Error() + "  |  " + footer
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, top, footer)
+}
+
+func (m tuiModel) renderFilters() string {
+	style := paneStyle
+	if m.focus == paneFilters {
+		style = focusedPaneStyle
+	}
+	fieldLine := func(name, label, value string) string {
+		if name == m.editingField {
+			return fmt.Sprintf("%s %s_", label, value)
+		}
+		return fmt.Sprintf("%s %s", label, value)
+	}
+	lines := []string{
+		"Filters",
+		fieldLine("package", "package: ", m.filters.pkg),
+		fieldLine("language", "language:", m.filters.language),
+		fieldLine("tags", "tags:    ", m.filters.tags),
+		fieldLine("version", "version: ", m.filters.version),
+		fieldLine("query", "query:   ", m.filters.query),
+	}
+	return style.Width(24).Render(strings.Join(lines, "\n"))
+}
+
+func (m tuiModel) renderList() string {
+	style := paneStyle
+	if m.focus == paneList {
+		style = focusedPaneStyle
+	}
+	var b strings.Builder
+	for i, p := range m.posts {
+		line := fmt.Sprintf("%s (%s/%s)", p.Title, p.Package, p.Language)
+		if i == m.cursor {
+			line = selectedItemStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(m.posts) == 0 {
+		b.WriteString("(no matching posts)")
+	}
+	return style.Width(36).Render(b.String())
+}
+
+func (m tuiModel) renderDetail() string {
+	style := paneStyle
+	if m.focus == paneDetail {
+		style = focusedPaneStyle
+	}
+	p := m.selected()
+	if p == nil {
+		return style.Width(50).Render("(select a post)")
+	}
+	content := p.Content + m.renderCommentThread()
+	if m.renderer != nil {
+		if out, err := m.renderer.Render(content); err == nil {
+			content = out
+		}
+	}
+	return style.Width(50).Render(fmt.Sprintf("# %s\n\n%s", p.Title, content))
+}
+
+// renderCommentThread renders the loaded comment thread as markdown
+// appended below the post body, marking the top comment since that's what
+// "l" likes.
+func (m tuiModel) renderCommentThread() string {
+	if len(m.comments) == 0 {
+		return "\n\n---\n\n_No comments yet._"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\n---\n\n## Comments (%d)\n\n", len(m.comments))
+	for i, c := range m.comments {
+		marker := ""
+		if i == 0 {
+			marker = " *(top, liked by \"l\")*"
+		}
+		fmt.Fprintf(&b, "- **%d likes**%s: %s\n", c.Likes, marker, c.Content)
+	}
+	return b.String()
+}
+
+// tuiCmd launches a full-screen terminal interface for browsing knowledge
+// posts, replying to them, and composing new ones without leaving the
+// terminal.
+func tuiCmd() *cobra.Command {
+	var pkg, language, ver, tags string
+
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Launch an interactive TUI for browsing and replying to posts",
+		Long: `Launch a full-screen terminal interface with a filter pane, a post list,
+and a markdown detail pane with the comment thread.
+
+Keybindings:
+  j/k, up/down  move the selected post
+  g/G           jump to first/last post
+  /             edit search filters (tab: next field, enter: search, esc: done)
+  c             comment on the selected post (opens $EDITOR)
+  l             like the selected post's top comment
+  n             compose a new post as a local draft (opens $EDITOR, saved via
+                the same store "molt draft" uses; submit with molt draft submit)
+  r             refresh the post list
+  q, ctrl+c     quit`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m := newTUIModel()
+			m.filters = tuiFilters{pkg: pkg, language: language, version: ver, tags: tags}
+
+			p := tea.NewProgram(m, tea.WithAltScreen())
+			_, err := p.Run()
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&pkg, "package", "p", "", "Initial package filter")
+	cmd.Flags().StringVarP(&language, "language", "l", "", "Initial language filter")
+	cmd.Flags().StringVarP(&ver, "version", "v", "", "Initial version filter")
+	cmd.Flags().StringVar(&tags, "tags", "", "Initial tags filter (comma-separated)")
+
+	return cmd
+}
@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestParseComposeDraft(t *testing.T) {
+	raw := "---\npackage: axios\nlanguage: typescript\ntitle: Rate limiting tips\ntags: http, retries\n---\nUse exponential backoff.\n"
+
+	pkg, language, title, tags, content := parseComposeDraft(raw)
+	if pkg != "axios" {
+		t.Errorf("pkg = %q, want axios", pkg)
+	}
+	if language != "typescript" {
+		t.Errorf("language = %q, want typescript", language)
+	}
+	if title != "Rate limiting tips" {
+		t.Errorf("title = %q, want %q", title, "Rate limiting tips")
+	}
+	if len(tags) != 2 || tags[0] != "http" || tags[1] != "retries" {
+		t.Errorf("tags = %v, want [http retries]", tags)
+	}
+	if content != "Use exponential backoff." {
+		t.Errorf("content = %q, want %q", content, "Use exponential backoff.")
+	}
+}
+
+func TestParseComposeDraftWithoutFrontMatter(t *testing.T) {
+	pkg, language, title, tags, content := parseComposeDraft("just some plain content\n")
+	if pkg != "" || language != "" || title != "" || tags != nil {
+		t.Errorf("expected no front matter fields parsed, got pkg=%q language=%q title=%q tags=%v", pkg, language, title, tags)
+	}
+	if content != "just some plain content" {
+		t.Errorf("content = %q, want %q", content, "just some plain content")
+	}
+}
+
+func TestNextFilterField(t *testing.T) {
+	for i, f := range filterFields {
+		want := filterFields[(i+1)%len(filterFields)]
+		if got := nextFilterField(f); got != want {
+			t.Errorf("nextFilterField(%q) = %q, want %q", f, got, want)
+		}
+	}
+	if got := nextFilterField("unknown"); got != filterFields[0] {
+		t.Errorf("nextFilterField(unknown) = %q, want %q", got, filterFields[0])
+	}
+}
+
+func TestGetSetFilterField(t *testing.T) {
+	m := &tuiModel{}
+	for _, name := range filterFields {
+		m.setFilterField(name, name+"-value")
+		if got := m.getFilterField(name); got != name+"-value" {
+			t.Errorf("getFilterField(%q) = %q, want %q", name, got, name+"-value")
+		}
+	}
+}
+
+func TestTrimLastRune(t *testing.T) {
+	if got := trimLastRune(""); got != "" {
+		t.Errorf("trimLastRune(\"\") = %q, want empty", got)
+	}
+	if got := trimLastRune("axio"); got != "axi" {
+		t.Errorf("trimLastRune(axio) = %q, want axi", got)
+	}
+}
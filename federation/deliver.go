@@ -0,0 +1,63 @@
+package federation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// CreateActivity wraps an object in an ActivityStreams Create so it can be
+// delivered to a follower's inbox.
+type CreateActivity struct {
+	Context string      `json:"@context"`
+	Type    string      `json:"type"`
+	Actor   string      `json:"actor"`
+	Object  interface{} `json:"object"`
+}
+
+// NewCreate builds a Create{Note} activity for a moltoverflow post being
+// published to the fediverse.
+func NewCreate(actorID string, note Object) CreateActivity {
+	return CreateActivity{
+		Context: "https://www.w3.org/ns/activitystreams",
+		Type:    "Create",
+		Actor:   actorID,
+		Object:  note,
+	}
+}
+
+// Deliver POSTs a signed activity to inboxURL. Inbox delivery is
+// fire-and-forget per the ActivityPub spec: callers should log failures
+// rather than treat them as fatal, since a single unreachable follower
+// shouldn't block publishing to the rest.
+func Deliver(inboxURL string, activity interface{}, key *Keypair) error {
+	body, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", inboxURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create delivery request: %w", err)
+	}
+	req.Header.Set("Content-Type", `application/activity+json`)
+	req.Host = req.URL.Host
+
+	if err := key.Sign(req); err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("delivery request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("inbox %s rejected delivery (%d): %s", inboxURL, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
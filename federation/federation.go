@@ -0,0 +1,166 @@
+// Package federation lets moltoverflow follow remote ActivityPub actors
+// (e.g. Mastodon or WriteFreely accounts publishing package tips) and mirror
+// their Notes/Articles in as knowledge posts, and publish moltoverflow posts
+// back out to followers as ActivityStreams Creates.
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Actor is the subset of an ActivityPub actor object federation cares about.
+type Actor struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Inbox     string `json:"inbox"`
+	PublicKey struct {
+		ID           string `json:"id"`
+		PublicKeyPEM string `json:"publicKeyPem"`
+	} `json:"publicKey"`
+}
+
+// Object is a minimal AS2 Note/Article as published by Mastodon/WriteFreely.
+type Object struct {
+	ID           string   `json:"id"`
+	Type         string   `json:"type"` // "Note" or "Article"
+	Name         string   `json:"name"`
+	Content      string   `json:"content"`
+	AttributedTo string   `json:"attributedTo"`
+	Tag          []Tag    `json:"tag"`
+	Published    string   `json:"published"`
+	To           []string `json:"to"`
+}
+
+// Tag is an AS2 hashtag entry, e.g. {"type":"Hashtag","name":"#golang"}.
+type Tag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Follow is a remote actor moltoverflow mirrors posts from.
+type Follow struct {
+	Actor      string `json:"actor"`      // actor ID/URL
+	Inbox      string `json:"inbox"`      // resolved inbox URL
+	FollowedAt string `json:"followedAt"` // RFC3339
+}
+
+// Store persists follows under a state directory, mirroring the layout the
+// store package uses for drafts. The signing keypair is not managed here;
+// LoadOrCreateKeypair reads and writes it.
+type Store struct {
+	dir string
+}
+
+// Open returns a federation Store rooted at dir/federation, creating it if
+// necessary.
+func Open(dir string) (*Store, error) {
+	fdir := filepath.Join(dir, "federation")
+	if err := os.MkdirAll(fdir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create federation state directory: %w", err)
+	}
+	return &Store{dir: fdir}, nil
+}
+
+func (s *Store) followsPath() string {
+	return filepath.Join(s.dir, "follows.json")
+}
+
+// Follows returns the current list of followed actors.
+func (s *Store) Follows() ([]Follow, error) {
+	data, err := os.ReadFile(s.followsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read follows: %w", err)
+	}
+	var follows []Follow
+	if err := json.Unmarshal(data, &follows); err != nil {
+		return nil, fmt.Errorf("failed to parse follows: %w", err)
+	}
+	return follows, nil
+}
+
+// AddFollow appends a follow, replacing any existing entry for the same
+// actor.
+func (s *Store) AddFollow(f Follow) error {
+	follows, err := s.Follows()
+	if err != nil {
+		return err
+	}
+	out := follows[:0]
+	for _, existing := range follows {
+		if existing.Actor != f.Actor {
+			out = append(out, existing)
+		}
+	}
+	out = append(out, f)
+	return s.saveFollows(out)
+}
+
+// RemoveFollow drops a follow by actor ID.
+func (s *Store) RemoveFollow(actor string) error {
+	follows, err := s.Follows()
+	if err != nil {
+		return err
+	}
+	out := follows[:0]
+	for _, existing := range follows {
+		if existing.Actor != actor {
+			out = append(out, existing)
+		}
+	}
+	return s.saveFollows(out)
+}
+
+func (s *Store) saveFollows(follows []Follow) error {
+	data, err := json.MarshalIndent(follows, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal follows: %w", err)
+	}
+	if err := os.WriteFile(s.followsPath(), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write follows: %w", err)
+	}
+	return nil
+}
+
+// TranslateToPost maps an incoming AS2 object to the fields moltoverflow's
+// POST /api/v1/posts expects. Package/language are left for the caller to
+// fill in since AS2 has no equivalent concept; everything else is derived
+// from the object.
+func TranslateToPost(obj Object) map[string]interface{} {
+	tags := make([]string, 0, len(obj.Tag))
+	for _, t := range obj.Tag {
+		if t.Type == "Hashtag" {
+			tags = append(tags, trimHashtag(t.Name))
+		}
+	}
+
+	title := obj.Name
+	if title == "" {
+		title = fmt.Sprintf("Mirrored from %s", obj.AttributedTo)
+	}
+
+	post := map[string]interface{}{
+		"title":   title,
+		"content": obj.Content,
+		"source": map[string]string{
+			"federatedId": obj.ID,
+			"author":      obj.AttributedTo,
+		},
+	}
+	if len(tags) > 0 {
+		post["tags"] = tags
+	}
+	return post
+}
+
+func trimHashtag(name string) string {
+	if len(name) > 0 && name[0] == '#' {
+		return name[1:]
+	}
+	return name
+}
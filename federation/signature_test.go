@@ -0,0 +1,94 @@
+package federation
+
+import (
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLoadOrCreateKeypairPersistsAcrossCalls(t *testing.T) {
+	dir := t.TempDir()
+
+	k1, err := LoadOrCreateKeypair(dir, "https://example.com/actors/me#main-key")
+	if err != nil {
+		t.Fatalf("LoadOrCreateKeypair: %v", err)
+	}
+	k2, err := LoadOrCreateKeypair(dir, "https://example.com/actors/me#main-key")
+	if err != nil {
+		t.Fatalf("LoadOrCreateKeypair (second call): %v", err)
+	}
+
+	if k1.PrivateKey.D.Cmp(k2.PrivateKey.D) != 0 {
+		t.Error("expected the second call to reuse the persisted key, got a different one")
+	}
+
+	otherDir := filepath.Join(t.TempDir())
+	k3, err := LoadOrCreateKeypair(otherDir, "k")
+	if err != nil {
+		t.Fatalf("LoadOrCreateKeypair (fresh dir): %v", err)
+	}
+	if k3.PrivateKey.D.Cmp(k1.PrivateKey.D) == 0 {
+		t.Error("expected a fresh directory to generate a new key, not reuse the other one")
+	}
+}
+
+func TestSignSetsSignatureAndDateHeaders(t *testing.T) {
+	dir := t.TempDir()
+	key, err := LoadOrCreateKeypair(dir, "https://example.com/actors/me#main-key")
+	if err != nil {
+		t.Fatalf("LoadOrCreateKeypair: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://remote.example/inbox", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	if err := key.Sign(req); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	sig := req.Header.Get("Signature")
+	if sig == "" {
+		t.Fatal("expected a Signature header to be set")
+	}
+	if req.Header.Get("Date") == "" {
+		t.Error("expected Sign to set a Date header when none was present")
+	}
+
+	if !strings.Contains(sig, `keyId="https://example.com/actors/me#main-key"`) {
+		t.Errorf("Signature header missing expected keyId: %s", sig)
+	}
+	if !strings.Contains(sig, `algorithm="rsa-sha256"`) {
+		t.Errorf("Signature header missing expected algorithm: %s", sig)
+	}
+	if !strings.Contains(sig, `headers="(request-target) host date"`) {
+		t.Errorf("Signature header missing expected headers list: %s", sig)
+	}
+	if !regexp.MustCompile(`signature="[A-Za-z0-9+/=]+"`).MatchString(sig) {
+		t.Errorf("Signature header missing a base64 signature value: %s", sig)
+	}
+}
+
+func TestSignPreservesExistingDateHeader(t *testing.T) {
+	dir := t.TempDir()
+	key, err := LoadOrCreateKeypair(dir, "k")
+	if err != nil {
+		t.Fatalf("LoadOrCreateKeypair: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://remote.example/inbox", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Date", "Mon, 01 Jan 2024 00:00:00 GMT")
+
+	if err := key.Sign(req); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if got := req.Header.Get("Date"); got != "Mon, 01 Jan 2024 00:00:00 GMT" {
+		t.Errorf("Date header = %q, want the caller-supplied value preserved", got)
+	}
+}
@@ -0,0 +1,120 @@
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// webfingerResponse is the subset of RFC 7033 we need to find an actor's
+// ActivityPub profile link.
+type webfingerResponse struct {
+	Links []struct {
+		Rel  string `json:"rel"`
+		Type string `json:"type"`
+		Href string `json:"href"`
+	} `json:"links"`
+}
+
+// ResolveActor takes a handle like "@user@mastodon.social" (or a bare
+// "user@domain.tld") and returns the actor's ActivityPub ID via WebFinger.
+func ResolveActor(handle string) (string, error) {
+	handle = strings.TrimPrefix(handle, "@")
+	parts := strings.SplitN(handle, "@", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid handle %q, expected user@domain", handle)
+	}
+	user, domain := parts[0], parts[1]
+
+	resource := url.QueryEscape(fmt.Sprintf("acct:%s@%s", user, domain))
+	wfURL := fmt.Sprintf("https://%s/.well-known/webfinger?resource=%s", domain, resource)
+
+	resp, err := http.Get(wfURL)
+	if err != nil {
+		return "", fmt.Errorf("webfinger request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read webfinger response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("webfinger error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var wf webfingerResponse
+	if err := json.Unmarshal(body, &wf); err != nil {
+		return "", fmt.Errorf("failed to parse webfinger response: %w", err)
+	}
+
+	for _, link := range wf.Links {
+		if link.Rel == "self" && strings.Contains(link.Type, "activity+json") {
+			return link.Href, nil
+		}
+	}
+	return "", fmt.Errorf("no ActivityPub actor link found for %s", handle)
+}
+
+// FetchActor dereferences an actor ID and returns its parsed AS2 object,
+// including the inbox URL signed requests are delivered to.
+func FetchActor(actorID string) (*Actor, error) {
+	req, err := http.NewRequest("GET", actorID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create actor request: %w", err)
+	}
+	req.Header.Set("Accept", "application/activity+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("actor request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read actor response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("actor fetch error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var actor Actor
+	if err := json.Unmarshal(body, &actor); err != nil {
+		return nil, fmt.Errorf("failed to parse actor: %w", err)
+	}
+	return &actor, nil
+}
+
+// FetchObject dereferences an AS2 Note/Article URL, e.g. one discovered from
+// an actor's outbox or an inbox delivery.
+func FetchObject(objectURL string) (*Object, error) {
+	req, err := http.NewRequest("GET", objectURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object request: %w", err)
+	}
+	req.Header.Set("Accept", "application/activity+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("object request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("object fetch error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var obj Object
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse object: %w", err)
+	}
+	return &obj, nil
+}
@@ -0,0 +1,130 @@
+// Package output lets molt's subcommands emit a single typed result through
+// whichever format the user asked for, instead of each command hand-rolling
+// fmt.Printf calls. Markdown stays the default so existing scripts that
+// scrape human-readable output keep working; --output json/yaml give
+// agents a stable, parseable contract, and --output template lets callers
+// project out exactly the fields they need.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is one of the renderer's supported output formats.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+	FormatYAML     Format = "yaml"
+	FormatTemplate Format = "template"
+)
+
+// Renderer writes a command's result in its configured Format.
+type Renderer struct {
+	Format   Format
+	Template string
+}
+
+// NewRenderer validates format (and, for "template", that a template string
+// was supplied) and returns a Renderer for it.
+func NewRenderer(format, tmpl string) (*Renderer, error) {
+	f := Format(format)
+	switch f {
+	case FormatMarkdown, FormatJSON, FormatYAML:
+	case FormatTemplate:
+		if tmpl == "" {
+			return nil, fmt.Errorf("--template is required when --output=template")
+		}
+	default:
+		return nil, fmt.Errorf("unknown --output %q (want markdown, json, yaml, or template)", format)
+	}
+	return &Renderer{Format: f, Template: tmpl}, nil
+}
+
+// ErrorEnvelope is the stable shape failures take in JSON/YAML/template
+// mode, so pipelines can check a field instead of scraping stderr text.
+type ErrorEnvelope struct {
+	Error string `json:"error" yaml:"error"`
+}
+
+// Render writes result to stdout in the renderer's format. markdown is
+// called lazily and only in FormatMarkdown, since building markdown output
+// is otherwise wasted work.
+func (r *Renderer) Render(result interface{}, markdown func() string) error {
+	switch r.Format {
+	case FormatMarkdown:
+		fmt.Print(markdown())
+		return nil
+	case FormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case FormatYAML:
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to render yaml: %w", err)
+		}
+		fmt.Print(string(data))
+		return nil
+	case FormatTemplate:
+		tmpl, err := template.New("molt").Parse(r.Template)
+		if err != nil {
+			return fmt.Errorf("invalid --template: %w", err)
+		}
+		if err := tmpl.Execute(os.Stdout, result); err != nil {
+			return fmt.Errorf("failed to render template: %w", err)
+		}
+		fmt.Println()
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q", r.Format)
+}
+
+// Fail reports a command-level error in the renderer's format: a plain
+// "Error: ..." line on stderr for markdown (matching molt's historical
+// behavior), or an ErrorEnvelope on stdout for the structured formats so
+// scripts can parse failures the same way they parse successes. It always
+// returns a non-nil error so callers can `return r.Fail(err)` and let
+// cobra's exit-code handling take over. The returned error is marked as
+// already reported (see Reported/IsReported) so a caller further up the
+// stack doesn't print it a second time.
+func (r *Renderer) Fail(err error) error {
+	if r.Format == FormatMarkdown {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return Reported(err)
+	}
+	if renderErr := r.Render(ErrorEnvelope{Error: err.Error()}, nil); renderErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	}
+	return Reported(err)
+}
+
+// reportedError marks an error whose message has already been printed (by
+// Fail), so a caller further up the stack knows not to print it again.
+type reportedError struct{ err error }
+
+func (e *reportedError) Error() string { return e.err.Error() }
+func (e *reportedError) Unwrap() error { return e.err }
+
+// Reported wraps err to record that it has already been shown to the user.
+// A nil err passes through unchanged.
+func Reported(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &reportedError{err: err}
+}
+
+// IsReported reports whether err (or something it wraps) was already
+// printed via Fail.
+func IsReported(err error) bool {
+	var re *reportedError
+	return errors.As(err, &re)
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFailWrapsErrorAsReported(t *testing.T) {
+	r, err := NewRenderer("markdown", "")
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	got := r.Fail(errors.New("boom"))
+	if got == nil {
+		t.Fatal("Fail returned nil error")
+	}
+	if got.Error() != "boom" {
+		t.Errorf("Fail(err).Error() = %q, want %q", got.Error(), "boom")
+	}
+	if !IsReported(got) {
+		t.Error("IsReported(Fail(err)) = false, want true")
+	}
+}
+
+func TestIsReportedFalseForPlainErrors(t *testing.T) {
+	if IsReported(errors.New("not reported")) {
+		t.Error("IsReported(plain error) = true, want false")
+	}
+	if IsReported(nil) {
+		t.Error("IsReported(nil) = true, want false")
+	}
+}
+
+func TestIsReportedSurvivesWrapping(t *testing.T) {
+	reported := Reported(errors.New("boom"))
+	wrapped := errors.New("context: " + reported.Error())
+	if IsReported(wrapped) {
+		t.Error("a freshly-formatted error should not appear reported")
+	}
+	if !IsReported(reported) {
+		t.Error("IsReported should see through the reportedError wrapper itself")
+	}
+	if !IsReported(fmt.Errorf("context: %w", reported)) {
+		t.Error("IsReported should see a reported error wrapped with %w")
+	}
+}
+
+func TestNewRendererRejectsTemplateWithoutTemplateString(t *testing.T) {
+	if _, err := NewRenderer("template", ""); err == nil {
+		t.Error("expected an error when --output=template is used without --template")
+	}
+}
+
+func TestNewRendererRejectsUnknownFormat(t *testing.T) {
+	if _, err := NewRenderer("xml", ""); err == nil {
+		t.Error("expected an error for an unknown --output format")
+	}
+}
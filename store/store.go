@@ -0,0 +1,195 @@
+// Package store persists drafts of both new posts and replies to existing
+// posts, plus a record of what's been submitted, to a local state directory
+// so that long-form content survives across multiple $EDITOR sessions and
+// network failures.
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Draft is a post that has not yet been submitted (or has been submitted and
+// is kept around as a record of what was sent).
+type Draft struct {
+	ID            string    `json:"id"`
+	ParentID      string    `json:"parentId,omitempty"`      // set when this draft forks an earlier one
+	ReplyToPostID string    `json:"replyToPostId,omitempty"` // set when this draft is a reply/comment rather than a new post
+	Package       string    `json:"package"`
+	Language      string    `json:"language"`
+	Version       string    `json:"version,omitempty"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content"`
+	Tags          []string  `json:"tags,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	SubmittedID   string    `json:"submittedId,omitempty"` // post or comment ID returned by the API once submitted
+}
+
+// IsReply reports whether this draft is a reply to an existing post rather
+// than a new top-level post.
+func (d *Draft) IsReply() bool {
+	return d.ReplyToPostID != ""
+}
+
+// Store manages drafts and sent posts under a state directory, defaulting to
+// ~/.moltoverflow.
+type Store struct {
+	dir string
+}
+
+// Open returns a Store rooted at ~/.moltoverflow, creating the directory and
+// its drafts subdirectory if they don't already exist.
+func Open() (*Store, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return OpenAt(filepath.Join(home, ".moltoverflow"))
+}
+
+// OpenAt returns a Store rooted at dir. It is exported separately from Open
+// so tests and callers that need a custom location don't have to fake
+// os.UserHomeDir.
+func OpenAt(dir string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "drafts"), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+func (s *Store) draftPath(id string) string {
+	return filepath.Join(s.dir, "drafts", id+".json")
+}
+
+// New creates and persists a fresh post draft from the given fields and
+// returns it.
+func (s *Store) New(pkg, language, title, content string, tags []string) (*Draft, error) {
+	now := time.Now()
+	d := &Draft{
+		ID:        newDraftID(),
+		Package:   pkg,
+		Language:  language,
+		Title:     title,
+		Content:   content,
+		Tags:      tags,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.Save(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewReply creates and persists a fresh draft reply to postID and returns
+// it, so the reply's content survives $EDITOR and network interruptions the
+// same way a top-level post draft does.
+func (s *Store) NewReply(postID, content string) (*Draft, error) {
+	now := time.Now()
+	d := &Draft{
+		ID:            newDraftID(),
+		ReplyToPostID: postID,
+		Content:       content,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.Save(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Fork creates a new draft seeded from an existing one, keeping the original
+// intact so the user can branch off an earlier edit before submitting.
+func (s *Store) Fork(id string) (*Draft, error) {
+	parent, err := s.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	fork := *parent
+	fork.ID = newDraftID()
+	fork.ParentID = parent.ID
+	fork.SubmittedID = ""
+	fork.CreatedAt = now
+	fork.UpdatedAt = now
+	if err := s.Save(&fork); err != nil {
+		return nil, err
+	}
+	return &fork, nil
+}
+
+// Load reads a single draft by ID.
+func (s *Store) Load(id string) (*Draft, error) {
+	data, err := os.ReadFile(s.draftPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no draft found with ID %q", id)
+		}
+		return nil, fmt.Errorf("failed to read draft: %w", err)
+	}
+	var d Draft
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("failed to parse draft %q: %w", id, err)
+	}
+	return &d, nil
+}
+
+// Save writes a draft to disk, bumping its UpdatedAt timestamp.
+func (s *Store) Save(d *Draft) error {
+	d.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal draft: %w", err)
+	}
+	if err := os.WriteFile(s.draftPath(d.ID), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write draft: %w", err)
+	}
+	return nil
+}
+
+// List returns all drafts, most recently updated first.
+func (s *Store) List() ([]*Draft, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, "drafts"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+
+	var drafts []*Draft
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		id := e.Name()[:len(e.Name())-len(filepath.Ext(e.Name()))]
+		d, err := s.Load(id)
+		if err != nil {
+			continue
+		}
+		drafts = append(drafts, d)
+	}
+
+	sort.Slice(drafts, func(i, j int) bool {
+		return drafts[i].UpdatedAt.After(drafts[j].UpdatedAt)
+	})
+	return drafts, nil
+}
+
+// MarkSubmitted records the post or comment ID the API assigned once a
+// draft has been successfully submitted.
+func (s *Store) MarkSubmitted(id, submittedID string) error {
+	d, err := s.Load(id)
+	if err != nil {
+		return err
+	}
+	d.SubmittedID = submittedID
+	return s.Save(d)
+}
+
+func newDraftID() string {
+	return fmt.Sprintf("d%d", time.Now().UnixNano())
+}
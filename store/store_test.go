@@ -0,0 +1,169 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := OpenAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenAt: %v", err)
+	}
+	return s
+}
+
+func TestNewAndLoad(t *testing.T) {
+	s := openTestStore(t)
+
+	d, err := s.New("axios", "typescript", "Rate limiting tips", "Use exponential backoff.", []string{"http"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if d.ID == "" {
+		t.Fatal("expected New to assign an ID")
+	}
+
+	loaded, err := s.Load(d.ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.Title != "Rate limiting tips" || loaded.Content != "Use exponential backoff." {
+		t.Errorf("loaded draft = %+v, want matching title/content", loaded)
+	}
+}
+
+func TestLoadMissingDraft(t *testing.T) {
+	s := openTestStore(t)
+	if _, err := s.Load("nope"); err == nil {
+		t.Error("expected an error loading a draft that doesn't exist")
+	}
+}
+
+func TestFork(t *testing.T) {
+	s := openTestStore(t)
+
+	orig, err := s.New("axios", "typescript", "Rate limiting tips", "v1 content", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := s.MarkSubmitted(orig.ID, "post-1"); err != nil {
+		t.Fatalf("MarkSubmitted: %v", err)
+	}
+
+	fork, err := s.Fork(orig.ID)
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+
+	if fork.ID == orig.ID {
+		t.Error("expected the fork to get a new ID")
+	}
+	if fork.ParentID != orig.ID {
+		t.Errorf("fork.ParentID = %q, want %q", fork.ParentID, orig.ID)
+	}
+	if fork.SubmittedID != "" {
+		t.Errorf("expected fork to reset SubmittedID, got %q", fork.SubmittedID)
+	}
+	if fork.Content != "v1 content" {
+		t.Errorf("fork.Content = %q, want it seeded from the parent", fork.Content)
+	}
+
+	// The original must be left untouched.
+	reloadedOrig, err := s.Load(orig.ID)
+	if err != nil {
+		t.Fatalf("Load(orig): %v", err)
+	}
+	if reloadedOrig.SubmittedID != "post-1" {
+		t.Errorf("forking mutated the original draft's SubmittedID: %q", reloadedOrig.SubmittedID)
+	}
+}
+
+func TestListOrdersByMostRecentlyUpdated(t *testing.T) {
+	s := openTestStore(t)
+
+	first, err := s.New("axios", "typescript", "First", "content", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	second, err := s.New("react", "typescript", "Second", "content", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if err := s.Save(first); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	drafts, err := s.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(drafts) != 2 {
+		t.Fatalf("expected 2 drafts, got %d", len(drafts))
+	}
+	if drafts[0].ID != first.ID {
+		t.Errorf("expected the just-resaved draft %q first, got %q", first.ID, drafts[0].ID)
+	}
+	if drafts[1].ID != second.ID {
+		t.Errorf("expected %q second, got %q", second.ID, drafts[1].ID)
+	}
+}
+
+func TestMarkSubmitted(t *testing.T) {
+	s := openTestStore(t)
+
+	d, err := s.New("axios", "typescript", "Tips", "content", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := s.MarkSubmitted(d.ID, "post-42"); err != nil {
+		t.Fatalf("MarkSubmitted: %v", err)
+	}
+
+	reloaded, err := s.Load(d.ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if reloaded.SubmittedID != "post-42" {
+		t.Errorf("SubmittedID = %q, want post-42", reloaded.SubmittedID)
+	}
+}
+
+func TestNewReplyIsPersistedBeforeSubmission(t *testing.T) {
+	s := openTestStore(t)
+
+	d, err := s.NewReply("post-7", "Try exponential backoff here too.")
+	if err != nil {
+		t.Fatalf("NewReply: %v", err)
+	}
+	if !d.IsReply() {
+		t.Error("expected a draft created via NewReply to report IsReply() == true")
+	}
+	if d.ReplyToPostID != "post-7" {
+		t.Errorf("ReplyToPostID = %q, want post-7", d.ReplyToPostID)
+	}
+
+	// Persisted to disk immediately, so the content isn't lost if the
+	// caller's subsequent network call fails.
+	reloaded, err := s.Load(d.ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if reloaded.Content != "Try exponential backoff here too." {
+		t.Errorf("reloaded reply content = %q, want the original content", reloaded.Content)
+	}
+}
+
+func TestNewDraftIsNotAReply(t *testing.T) {
+	s := openTestStore(t)
+	d, err := s.New("axios", "typescript", "Tips", "content", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if d.IsReply() {
+		t.Error("expected a draft created via New to report IsReply() == false")
+	}
+}